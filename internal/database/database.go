@@ -63,11 +63,97 @@ CREATE TABLE IF NOT EXISTS throws (
     darts_thrown INT NOT NULL,
     created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
 );
+`
+
+	const gameTeamsTable = `
+CREATE TABLE IF NOT EXISTS game_teams (
+    id      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    name    TEXT NOT NULL,
+    seat    INT NOT NULL
+);
 `
 
 	const addWinnerColumn = `
 ALTER TABLE games
 ADD COLUMN IF NOT EXISTS winner_id UUID REFERENCES players(id);
+`
+
+	const addDartsColumn = `
+ALTER TABLE throws
+ADD COLUMN IF NOT EXISTS darts JSONB;
+`
+
+	const addOptionsColumn = `
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS options JSONB NOT NULL DEFAULT '{}';
+`
+
+	const addNumberColumn = `
+ALTER TABLE game_players
+ADD COLUMN IF NOT EXISTS number INT;
+`
+
+	const addDoubleInColumn = `
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS double_in BOOLEAN NOT NULL DEFAULT FALSE;
+`
+
+	// in_rule/out_rule replace the double_in/double_out flags; rows written
+	// before they existed are backfilled from the flags once. The flags are
+	// still written, true exactly when the rule is "double".
+	const addCheckRuleColumns = `
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS in_rule  TEXT,
+ADD COLUMN IF NOT EXISTS out_rule TEXT;
+`
+
+	const backfillCheckRules = `
+UPDATE games
+SET in_rule  = COALESCE(in_rule, CASE WHEN double_in THEN 'double' ELSE 'straight' END),
+    out_rule = COALESCE(out_rule, CASE WHEN double_out THEN 'double' ELSE 'straight' END)
+WHERE in_rule IS NULL OR out_rule IS NULL;
+`
+
+	const addTeamColumn = `
+ALTER TABLE game_players
+ADD COLUMN IF NOT EXISTS team_id UUID REFERENCES game_teams(id) ON DELETE CASCADE;
+`
+
+	const addWinnerTeamColumn = `
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS winner_team_id UUID REFERENCES game_teams(id);
+`
+
+	const addPlayerStartingScoreColumn = `
+ALTER TABLE game_players
+ADD COLUMN IF NOT EXISTS starting_score INT;
+`
+
+	const addFormatColumn = `
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS format TEXT NOT NULL DEFAULT 'firstTo';
+`
+
+	const addStarterColumn = `
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS starter TEXT NOT NULL DEFAULT 'alternate';
+`
+
+	const addBullOffColumns = `
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS bull_off        BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS bull_off_result JSONB;
+`
+
+	const addCheckoutDoubleColumn = `
+ALTER TABLE throws
+ADD COLUMN IF NOT EXISTS checkout_double INT;
+`
+
+	const addTiebreakColumn = `
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS tiebreak JSONB;
 `
 
 	if _, err := db.Exec(ctx, enablePgcrypto); err != nil {
@@ -82,9 +168,54 @@ ADD COLUMN IF NOT EXISTS winner_id UUID REFERENCES players(id);
 	if _, err := db.Exec(ctx, throwsTable); err != nil {
 		return err
 	}
+	if _, err := db.Exec(ctx, gameTeamsTable); err != nil {
+		return err
+	}
 	if _, err := db.Exec(ctx, addWinnerColumn); err != nil {
 		return err
 	}
+	if _, err := db.Exec(ctx, addDartsColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addOptionsColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addNumberColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addDoubleInColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addCheckRuleColumns); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, backfillCheckRules); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addTeamColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addWinnerTeamColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addPlayerStartingScoreColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addFormatColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addStarterColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addBullOffColumns); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addTiebreakColumn); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, addCheckoutDoubleColumn); err != nil {
+		return err
+	}
 
 	log.Println("game-api migrations applied")
 	return nil
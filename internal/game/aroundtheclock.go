@@ -0,0 +1,141 @@
+package game
+
+import "errors"
+
+// aroundTheClockTargets is the order players have to hit: 1 through 20,
+// then the bull.
+var aroundTheClockTargets = func() []int {
+	targets := make([]int, 0, 21)
+	for n := 1; n <= 20; n++ {
+		targets = append(targets, n)
+	}
+	return append(targets, BullSegment)
+}()
+
+// aroundTheClockHit reports whether d counts as a hit on target for the
+// configured ring.
+func aroundTheClockHit(d Dart, target int, ring string) bool {
+	if d.IsMiss() || d.Segment != target {
+		return false
+	}
+	switch ring {
+	case "singles":
+		return d.Multiplier == 1
+	case "doubles":
+		return d.Multiplier == 2
+	case "trebles":
+		// There is no treble bull; the inner bull stands in for it.
+		if target == BullSegment {
+			return d.Multiplier == 2
+		}
+		return d.Multiplier == 3
+	default:
+		return true
+	}
+}
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "AroundTheClock",
+			Description: "Hit 1 through 20 and then the bull, in order.",
+			Darts:       true,
+			Teams:       true,
+			Options: []ModeOption{
+				{Key: "ring", Type: "string", Default: "any", Values: []any{"any", "singles", "doubles", "trebles"}, Description: "ring that counts as a hit"},
+				{Key: "skipAhead", Type: "bool", Default: false, Description: "a double or treble advances two or three targets (ring any)"},
+			},
+		},
+		validate: func(req CreateGameRequest) error {
+			switch req.Config.Ring {
+			case "", "any", "singles", "doubles", "trebles":
+				return nil
+			}
+			return errors.New("ring must be one of any, singles, doubles, trebles")
+		},
+		start: startAroundTheClock,
+	})
+}
+
+// startAroundTheClock scores an Around the Clock match.
+//
+// Rules implemented:
+//   - Every player hits 1 through 20 and then the bull, in order
+//   - Ring restricts which segment counts: any (default), singles, doubles
+//     or trebles
+//   - With skipAhead (any ring only), a double advances two targets and a
+//     treble three; the bull always has to be hit itself
+//   - The first player to hit the bull wins the leg; ScoresByPlayer holds
+//     the number of targets completed
+func startAroundTheClock(g *play) {
+	state, scores := g.state, g.scores
+	ring := state.Config.Ring
+	skipAhead := state.Config.SkipAhead && (ring == "" || ring == "any")
+	last := len(aroundTheClockTargets) - 1
+
+	match := newMatchScore(state, 0)
+	g.match = &match
+
+	// Per-player index into aroundTheClockTargets for the CURRENT leg
+	progress := make([]int, len(state.Players))
+	resetLeg := func() {
+		for i := range progress {
+			progress[i] = 0
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+	}
+	resetLeg()
+
+	g.apply = func(t *Throw, idx int) {
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		legWon := false
+		advanced := 0
+		for _, d := range t.Darts {
+			target := aroundTheClockTargets[progress[idx]]
+			if !aroundTheClockHit(d, target, ring) {
+				continue
+			}
+
+			if progress[idx] == last {
+				progress[idx]++
+				advanced++
+				legWon = true
+				break
+			}
+
+			step := 1
+			if skipAhead {
+				step = d.Multiplier
+			}
+			next := min(progress[idx]+step, last)
+			advanced += next - progress[idx]
+			progress[idx] = next
+		}
+
+		leg.ScoresByPlayer[t.PlayerID] = progress[idx]
+		scores[idx].LastVisit = &advanced
+
+		if !legWon {
+			return
+		}
+
+		if !finishLeg(&match, t.PlayerID, *t) {
+			startNextLegOrSet(&match, 0, state.Players)
+			resetLeg()
+		}
+	}
+
+	g.report = func() {
+		for i := range scores {
+			if progress[i] > last {
+				// Finished: no target left.
+				scores[i].Target = nil
+				continue
+			}
+			target := aroundTheClockTargets[progress[i]]
+			scores[i].Target = &target
+		}
+	}
+}
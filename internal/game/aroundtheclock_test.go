@@ -0,0 +1,70 @@
+package game
+
+import "testing"
+
+func TestAroundTheClockHit(t *testing.T) {
+	tests := []struct {
+		dart   string
+		target int
+		ring   string
+		want   bool
+	}{
+		{dart: "S5", target: 5, want: true},
+		{dart: "T5", target: 5, ring: "any", want: true},
+		{dart: "S6", target: 5},
+		{dart: "M", target: 5},
+		{dart: "S5", target: 5, ring: "singles", want: true},
+		{dart: "D5", target: 5, ring: "singles"},
+		{dart: "D5", target: 5, ring: "doubles", want: true},
+		{dart: "T5", target: 5, ring: "doubles"},
+		{dart: "T5", target: 5, ring: "trebles", want: true},
+		{dart: "Bull", target: BullSegment, ring: "trebles", want: true},
+		{dart: "25", target: BullSegment, ring: "trebles"},
+	}
+	for _, tt := range tests {
+		d := testDarts(t, tt.dart)[0]
+		if got := aroundTheClockHit(d, tt.target, tt.ring); got != tt.want {
+			t.Errorf("aroundTheClockHit(%s, %d, %q) = %t, want %t", tt.dart, tt.target, tt.ring, got, tt.want)
+		}
+	}
+}
+
+func TestAroundTheClockLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "bull after 1-20",
+			config:  GameConfig{Mode: "AroundTheClock"},
+			players: []string{"a", "b"},
+			visits: []testVisit{
+				v("a", "S1", "S2", "S3"), v("b", "M"), v("a", "S4", "S5", "S6"), v("b", "M"),
+				v("a", "S7", "S8", "S9"), v("b", "M"), v("a", "S10", "S11", "S12"), v("b", "M"),
+				v("a", "S13", "S14", "S15"), v("b", "M"), v("a", "S16", "S17", "S18"), v("b", "M"),
+				v("a", "S19", "S20", "M"), v("b", "M"), v("a", "M", "25"),
+			},
+			want: "a",
+		},
+		{
+			name:    "skipAhead, bull hit itself",
+			config:  GameConfig{Mode: "AroundTheClock", ModeOptions: ModeOptions{SkipAhead: true}},
+			players: []string{"a", "b"},
+			visits: []testVisit{
+				v("a", "T1", "T4", "T7"), v("b", "M", "M", "M"),
+				v("a", "T10", "T13", "T16"), v("b", "M", "M", "M"),
+				v("a", "T19", "25"),
+			},
+			want: "a",
+		},
+		{
+			name:    "skipAhead ignored outside the any ring",
+			config:  GameConfig{Mode: "AroundTheClock", ModeOptions: ModeOptions{Ring: "trebles", SkipAhead: true}},
+			players: []string{"a", "b"},
+			visits: []testVisit{
+				v("a", "T1", "T2", "T3"), v("b", "M"), v("a", "T4", "T5", "T6"), v("b", "M"),
+				v("a", "T7", "T8", "T9"), v("b", "M"), v("a", "T10", "T11", "T12"), v("b", "M"),
+				v("a", "T13", "T14", "T15"), v("b", "M"), v("a", "T16", "T17", "T18"), v("b", "M"),
+				v("a", "T19", "T20", "Bull"),
+			},
+			want: "a",
+		},
+	})
+}
@@ -0,0 +1,96 @@
+package game
+
+import "strconv"
+
+// baseballInnings is the regulation length of a Baseball game.
+const baseballInnings = 9
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "Baseball",
+			Description: "Nine innings; inning N scores runs on number N.",
+			Darts:       true,
+			Teams:       true,
+			Options:     []ModeOption{},
+		},
+		start: startBaseball,
+	})
+}
+
+// startBaseball scores a Baseball match.
+//
+// Rules implemented:
+//   - Inning N targets number N; a single, double or treble of it scores 1,
+//     2 or 3 runs
+//   - Every player throws one visit per inning
+//   - The most runs after nine innings wins the leg; a tie goes to extra
+//     innings (targeting 10, 11, ... and wrapping past 20) until one player
+//     leads at the end of an inning
+//   - RoundScore rows hold the runs per inning for every player
+func startBaseball(g *play) {
+	state, scores := g.state, g.scores
+	match := newMatchScore(state, 0)
+	g.match = &match
+
+	// Per-player runs and innings played, plus the inning table, for the
+	// CURRENT leg
+	runs := make([]int, len(state.Players))
+	innings := newLegRounds(len(state.Players), baseballInnings)
+	var table []RoundScore
+	resetLeg := func() {
+		for i := range runs {
+			runs[i] = 0
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+		table = make([]RoundScore, 0, baseballInnings)
+		innings.reset()
+	}
+	resetLeg()
+
+	g.apply = func(t *Throw, idx int) {
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		inning := innings.play(idx)
+		number := numberForRound(inning)
+
+		visitRuns := 0
+		for _, d := range t.Darts {
+			if !d.IsMiss() && d.Segment == number {
+				visitRuns += d.Multiplier
+			}
+		}
+		runs[idx] += visitRuns
+
+		if len(table) < inning {
+			table = append(table, RoundScore{
+				Round:          inning,
+				Target:         strconv.Itoa(number),
+				ScoresByPlayer: make(map[string]int, len(state.Players)),
+				TotalsByPlayer: make(map[string]int, len(state.Players)),
+			})
+		}
+		table[inning-1].ScoresByPlayer[t.PlayerID] = visitRuns
+		table[inning-1].TotalsByPlayer[t.PlayerID] = runs[idx]
+
+		leg.ScoresByPlayer[t.PlayerID] = runs[idx]
+		scores[idx].LastVisit = &visitRuns
+
+		if innings.settle(&match, *t, 0, state.Players, runs, soleHighest) {
+			resetLeg()
+		}
+	}
+
+	g.report = func() {
+		for i := range scores {
+			r := runs[i]
+			scores[i].Points = &r
+			target := numberForRound(innings.next(i))
+			scores[i].Target = &target
+		}
+		inning := innings.current()
+		state.Round = &inning
+		state.Rounds = table
+	}
+}
@@ -0,0 +1,32 @@
+package game
+
+import "testing"
+
+func TestBaseballRuns(t *testing.T) {
+	state := testGame(t, GameConfig{Mode: "Baseball"}, []string{"a", "b"}, []testVisit{
+		v("a", "S1", "D1", "T2"), v("b", "M"),
+		v("a", "T2", "T2", "S3"),
+	})
+	if got := *scoreOf(state, "a").Points; got != 9 {
+		t.Errorf("%d runs, want 9", got)
+	}
+}
+
+func TestBaseballLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "most runs after nine innings",
+			config:  GameConfig{Mode: "Baseball"},
+			players: []string{"a", "b"},
+			visits:  append([]testVisit{v("a", "M"), v("b", "S1")}, repeat(8, v("a", "M"), v("b", "M"))...),
+			want:    "b",
+		},
+		{
+			name:    "extra inning on 10",
+			config:  GameConfig{Mode: "Baseball"},
+			players: []string{"a", "b"},
+			visits:  append(repeat(9, v("a", "M"), v("b", "M")), v("a", "S9"), v("b", "S10")),
+			want:    "b",
+		},
+	})
+}
@@ -0,0 +1,136 @@
+package game
+
+// bobs27Start is the score every player starts Bob's 27 with.
+const bobs27Start = 27
+
+// bobs27Targets are the doubles in play, in order: D1 to D20, then the bull.
+var bobs27Targets = aroundTheClockTargets
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "Bobs27",
+			Description: "Start on 27 and throw at D1-D20 and the bull in turn; hits add the double's value, a visit without one subtracts it.",
+			Darts:       true,
+			Teams:       true,
+			Options:     []ModeOption{},
+		},
+		start: startBobs27,
+	})
+}
+
+// startBobs27 scores a Bob's 27 match.
+//
+// Rules implemented:
+//   - Everyone starts on 27 and throws one visit at each double in turn,
+//     D1 to D20 and then the bull
+//   - Every hit adds the double's value; a visit without a hit subtracts it
+//   - Dropping below zero puts the player out for the rest of the leg
+//   - Once every player has finished or is out, the highest final score
+//     wins the leg (ties go to the player with more hits, then the earlier
+//     seat), so a solo run finishes as soon as it is over
+func startBobs27(g *play) {
+	state, scores := g.state, g.scores
+	match := newMatchScore(state, bobs27Start)
+	g.match = &match
+
+	// Per-player score, doubles hit and visits thrown for the CURRENT leg
+	points := make([]int, len(state.Players))
+	hits := make([]int, len(state.Players))
+	visits := make([]int, len(state.Players))
+	resetLeg := func() {
+		for i := range points {
+			points[i] = bobs27Start
+			hits[i] = 0
+			visits[i] = 0
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+	}
+	resetLeg()
+
+	isOut := func(i int) bool { return points[i] < 0 }
+	isDone := func(i int) bool { return isOut(i) || visits[i] >= len(bobs27Targets) }
+
+	legOver := func() bool {
+		for i := range points {
+			if !isDone(i) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// best returns the highest score (out players are always below zero),
+	// ties going to more hits and then the earlier seat.
+	best := func() int {
+		winner := 0
+		for i := range points {
+			if points[i] > points[winner] ||
+				(points[i] == points[winner] && hits[i] > hits[winner]) {
+				winner = i
+			}
+		}
+		return winner
+	}
+
+	nextIdx := 0
+
+	g.apply = func(t *Throw, idx int) {
+		if isDone(idx) {
+			return
+		}
+
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		target := bobs27Targets[visits[idx]]
+		visits[idx]++
+
+		visitHits := 0
+		for _, d := range t.Darts {
+			if !d.IsMiss() && d.Segment == target && d.Multiplier == 2 {
+				visitHits++
+			}
+		}
+
+		delta := visitHits * 2 * target
+		if visitHits == 0 {
+			delta = -2 * target
+		}
+		points[idx] += delta
+		hits[idx] += visitHits
+
+		leg.ScoresByPlayer[t.PlayerID] = points[idx]
+		scores[idx].LastVisit = &delta
+
+		nextIdx = nextActiveIndex(len(points), idx, func(i int) bool { return !isDone(i) })
+
+		if !legOver() {
+			return
+		}
+
+		winnerIdx := best()
+		if !finishLeg(&match, state.Players[winnerIdx].ID, *t) {
+			startNextLegOrSet(&match, bobs27Start, state.Players)
+			resetLeg()
+			nextIdx = (idx + 1) % len(state.Players)
+		}
+	}
+	g.next = func() int { return nextIdx }
+
+	g.report = func() {
+		round := len(bobs27Targets)
+		for i := range scores {
+			p := points[i]
+			out := isOut(i)
+			scores[i].Points = &p
+			scores[i].Out = &out
+			if !isDone(i) {
+				target := bobs27Targets[visits[i]]
+				scores[i].Target = &target
+				round = min(round, visits[i]+1)
+			}
+		}
+		state.Round = &round
+	}
+}
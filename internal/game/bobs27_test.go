@@ -0,0 +1,50 @@
+package game
+
+import "testing"
+
+func TestBobs27LegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "everyone out, highest wins",
+			config:  GameConfig{Mode: "Bobs27"},
+			players: []string{"a", "b"},
+			visits: append(
+				[]testVisit{v("a", "D1", "M", "M"), v("b", "M", "M", "M")},
+				append(repeat(4, v("a", "M", "M", "M"), v("b", "M", "M", "M")), v("a", "M", "M", "M"))...,
+			),
+			want: "b",
+		},
+		{
+			name:    "solo run over when out",
+			config:  GameConfig{Mode: "Bobs27"},
+			players: []string{"a"},
+			visits:  repeat(5, v("a", "M", "M", "M")),
+			want:    "a",
+		},
+	})
+}
+
+func TestBobs27Scores(t *testing.T) {
+	tests := []struct {
+		name       string
+		visits     []testVisit
+		wantPoints int
+		wantTarget int // 0 once out
+		wantOut    bool
+	}{
+		{name: "hits add the double", visits: []testVisit{v("a", "D1", "D1", "M")}, wantPoints: 31, wantTarget: 2},
+		{name: "a miss takes the double off", visits: []testVisit{v("a", "D1"), v("b", "M"), v("a", "S2", "T2", "M")}, wantPoints: 25, wantTarget: 3},
+		{name: "below zero is out", visits: append(repeat(4, v("a", "M"), v("b", "M")), v("a", "M")), wantPoints: -3, wantOut: true},
+	}
+	for _, tt := range tests {
+		state := testGame(t, GameConfig{Mode: "Bobs27"}, []string{"a", "b"}, tt.visits)
+		score := scoreOf(state, "a")
+		target := 0
+		if score.Target != nil {
+			target = *score.Target
+		}
+		if *score.Points != tt.wantPoints || target != tt.wantTarget || *score.Out != tt.wantOut {
+			t.Errorf("%s: points %d, target %d, out %t, want %d, %d, %t", tt.name, *score.Points, target, *score.Out, tt.wantPoints, tt.wantTarget, tt.wantOut)
+		}
+	}
+}
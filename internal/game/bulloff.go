@@ -0,0 +1,95 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// decideBullOff validates the bull-off entries for state and picks the
+// winner: the smallest distance or the best rank. Every player (every team,
+// in team games) throws exactly one dart; a tie for the closest has to be
+// thrown again, so it is an error.
+func decideBullOff(state *GameState, entries []BullOffEntry, at time.Time) (BullOff, error) {
+	if len(entries) == 0 {
+		return BullOff{}, errors.New("bull-off entries are required")
+	}
+
+	// side of each player: the player itself, or their team
+	sideOf := make(map[string]string, len(state.Players))
+	for _, p := range state.Players {
+		sideOf[p.ID] = p.ID
+		if p.TeamID != nil {
+			sideOf[p.ID] = *p.TeamID
+		}
+	}
+	sides := len(state.Players)
+	if len(state.Teams) > 0 {
+		sides = len(state.Teams)
+	}
+
+	byRank := entries[0].Rank != nil
+	thrown := make(map[string]bool, len(entries))
+	best, tied := -1, false
+	for i, e := range entries {
+		side, ok := sideOf[e.PlayerID]
+		if !ok {
+			return BullOff{}, fmt.Errorf("player %s is not part of this game", e.PlayerID)
+		}
+		if thrown[side] {
+			if len(state.Teams) > 0 {
+				return BullOff{}, fmt.Errorf("more than one bull-off entry for the team of player %s", e.PlayerID)
+			}
+			return BullOff{}, fmt.Errorf("more than one bull-off entry for player %s", e.PlayerID)
+		}
+		thrown[side] = true
+
+		if (e.Distance == nil) == (e.Rank == nil) {
+			return BullOff{}, errors.New("each bull-off entry needs either a distance or a rank")
+		}
+		if byRank != (e.Rank != nil) {
+			return BullOff{}, errors.New("bull-off entries must all use distance or all use rank")
+		}
+		if e.Distance != nil && *e.Distance < 0 {
+			return BullOff{}, errors.New("bull-off distance must be >= 0")
+		}
+		if e.Rank != nil && *e.Rank < 1 {
+			return BullOff{}, errors.New("bull-off rank must be >= 1")
+		}
+
+		switch {
+		case best == -1 || closerToBull(e, entries[best]) < 0:
+			best, tied = i, false
+		case closerToBull(e, entries[best]) == 0:
+			tied = true
+		}
+	}
+	if len(thrown) != sides {
+		return BullOff{}, errors.New("every player (every team in team games) needs a bull-off entry")
+	}
+	if tied {
+		return BullOff{}, errors.New("bull-off is tied for the closest; throw again")
+	}
+
+	winner := entries[best].PlayerID
+	return BullOff{
+		Entries:   entries,
+		WinnerID:  winner,
+		StarterID: sideOf[winner],
+		DecidedAt: at,
+	}, nil
+}
+
+// closerToBull compares two entries of the same kind: negative if e is
+// closer to the bull than b, zero if level.
+func closerToBull(e, b BullOffEntry) int {
+	switch {
+	case e.Rank != nil:
+		return *e.Rank - *b.Rank
+	case *e.Distance < *b.Distance:
+		return -1
+	case *e.Distance > *b.Distance:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecideBullOff(t *testing.T) {
+	dist := func(pid string, d float64) BullOffEntry { return BullOffEntry{PlayerID: pid, Distance: &d} }
+	rank := func(pid string, r int) BullOffEntry { return BullOffEntry{PlayerID: pid, Rank: &r} }
+
+	players := []GamePlayer{{ID: "a", Seat: 1}, {ID: "b", Seat: 2}, {ID: "c", Seat: 3}}
+	teamA, teamB := "A", "B"
+	teamPlayers := []GamePlayer{{ID: "a1", TeamID: &teamA}, {ID: "b1", TeamID: &teamB}, {ID: "a2", TeamID: &teamA}}
+	teams := []GameTeam{{ID: "A", PlayerIDs: []string{"a1", "a2"}}, {ID: "B", PlayerIDs: []string{"b1"}}}
+
+	tests := []struct {
+		name        string
+		teams       bool
+		entries     []BullOffEntry
+		wantWinner  string
+		wantStarter string
+		wantErr     bool
+	}{
+		{name: "closest distance", entries: []BullOffEntry{dist("a", 12), dist("b", 3.5), dist("c", 40)}, wantWinner: "b", wantStarter: "b"},
+		{name: "best rank", entries: []BullOffEntry{rank("a", 2), rank("b", 3), rank("c", 1)}, wantWinner: "c", wantStarter: "c"},
+		{name: "tie for second is fine", entries: []BullOffEntry{dist("a", 0), dist("b", 5), dist("c", 5)}, wantWinner: "a", wantStarter: "a"},
+		{name: "team winner starts for the team", teams: true, entries: []BullOffEntry{dist("b1", 9), dist("a2", 4)}, wantWinner: "a2", wantStarter: "A"},
+		{name: "tie for the closest", entries: []BullOffEntry{dist("a", 5), dist("b", 5), dist("c", 9)}, wantErr: true},
+		{name: "tie for the best rank", entries: []BullOffEntry{rank("a", 1), rank("b", 1), rank("c", 3)}, wantErr: true},
+		{name: "no entries", wantErr: true},
+		{name: "missing player", entries: []BullOffEntry{dist("a", 1), dist("b", 2)}, wantErr: true},
+		{name: "player twice", entries: []BullOffEntry{dist("a", 1), dist("a", 2), dist("b", 3)}, wantErr: true},
+		{name: "team twice", teams: true, entries: []BullOffEntry{dist("a1", 1), dist("a2", 2), dist("b1", 3)}, wantErr: true},
+		{name: "unknown player", entries: []BullOffEntry{dist("a", 1), dist("b", 2), dist("x", 3)}, wantErr: true},
+		{name: "mixed kinds", entries: []BullOffEntry{dist("a", 1), rank("b", 2), dist("c", 3)}, wantErr: true},
+		{name: "neither kind", entries: []BullOffEntry{dist("a", 1), {PlayerID: "b"}, dist("c", 3)}, wantErr: true},
+		{name: "negative distance", entries: []BullOffEntry{dist("a", -1), dist("b", 2), dist("c", 3)}, wantErr: true},
+		{name: "rank 0", entries: []BullOffEntry{rank("a", 0), rank("b", 2), rank("c", 3)}, wantErr: true},
+	}
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		state := &GameState{Players: players}
+		if tt.teams {
+			state = &GameState{Players: teamPlayers, Teams: teams}
+		}
+		got, err := decideBullOff(state, tt.entries, at)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %t", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (got.WinnerID != tt.wantWinner || got.StarterID != tt.wantStarter || !got.DecidedAt.Equal(at)) {
+			t.Errorf("%s: won by %q, starter %q, want %q, %q", tt.name, got.WinnerID, got.StarterID, tt.wantWinner, tt.wantStarter)
+		}
+	}
+}
+
+func TestBullOffStartsFirstLeg(t *testing.T) {
+	config := GameConfig{Mode: "X01", StartingScore: intPtr(40), OutRule: RuleStraight, Legs: 3, BullOff: true}
+	state := &GameState{Config: config, BullOff: &BullOff{WinnerID: "b", StarterID: "b"}}
+	for i, id := range []string{"a", "b", "c"} {
+		state.Players = append(state.Players, GamePlayer{ID: id, Seat: i + 1})
+	}
+	(&Repository{}).computeScores(state)
+	if state.CurrentPlayerID != "b" {
+		t.Errorf("first leg starts with %q, want the bull-off winner b", state.CurrentPlayerID)
+	}
+
+	addVisit(t, state, vt("b", 40))
+	(&Repository{}).computeScores(state)
+	if state.CurrentPlayerID != "c" {
+		t.Errorf("second leg starts with %q, want c", state.CurrentPlayerID)
+	}
+}
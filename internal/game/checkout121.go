@@ -0,0 +1,158 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// defaultCheckout121Start is the first checkout target.
+	defaultCheckout121Start = 121
+	// checkout121Darts is the number of darts per checkout attempt.
+	checkout121Darts = 9
+	// checkout121MaxStart is the highest first target: 170 is the highest
+	// checkout with three darts.
+	checkout121MaxStart = 170
+	// defaultCheckout121Attempts is the number of attempts per player when the
+	// config does not set rounds.
+	defaultCheckout121Attempts = 10
+)
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "Checkout121",
+			Description: "Nine darts to check out the target; each checkout moves it up by one.",
+			Teams:       true,
+			Checkouts:   true,
+			Options: []ModeOption{
+				{Key: "startingScore", Type: "int", Default: defaultCheckout121Start, Description: "first checkout target, 2 to 170"},
+				{Key: "rounds", Type: "int", Default: defaultCheckout121Attempts, Description: "attempts per player"},
+				{Key: "outRule", Type: "string", Default: RuleDouble, Values: []any{RuleStraight, RuleDouble, RuleMaster}, Description: "dart needed to check out"},
+				{Key: "noBullFinish", Type: "bool", Default: false, Description: "the inner bull does not count as a double for the out-rule"},
+				{Key: "onFail", Type: "string", Default: "stay", Values: []any{"stay", "reset"}, Description: "target after a failed attempt"},
+			},
+		},
+		validate: func(req CreateGameRequest) error {
+			if s := req.Config.StartingScore; s != nil && (*s < 2 || *s > checkout121MaxStart) {
+				return fmt.Errorf("startingScore must be between 2 and %d", checkout121MaxStart)
+			}
+			if req.Config.Rounds < 0 {
+				return errors.New("rounds must be > 0, or 0 for the default")
+			}
+			if req.Config.OnFail != "" && req.Config.OnFail != "stay" && req.Config.OnFail != "reset" {
+				return errors.New("onFail must be stay or reset")
+			}
+			return nil
+		},
+		start: startCheckout121,
+	})
+}
+
+// startCheckout121 scores a Checkout121 match.
+//
+// Rules implemented:
+//   - Each attempt starts on the player's current target (121 by default)
+//     with 9 darts to check out, using the X01 bust and out-rules; a visit
+//     cannot take more darts than the attempt has left (see AddThrow)
+//   - A checkout moves the target up by one; a failed attempt keeps it, or
+//     drops it back to the start with onFail "reset"
+//   - Every player plays the configured number of attempts (rounds, default
+//     10); the highest target reached wins the leg, and a tie is played off
+//     with one more attempt each
+func startCheckout121(g *play) {
+	state, scores := g.state, g.scores
+	start := defaultCheckout121Start
+	if state.Config.StartingScore != nil {
+		start = *state.Config.StartingScore
+	}
+	attemptsToPlay := state.Config.Rounds
+	if attemptsToPlay <= 0 {
+		attemptsToPlay = defaultCheckout121Attempts
+	}
+	outRule := finishRule{rule: state.Config.OutRule, noBull: state.Config.NoBullFinish}
+	if outRule.rule == "" {
+		outRule.rule = RuleDouble
+	}
+
+	match := newMatchScore(state, start)
+	g.match = &match
+
+	// Per-player state of the CURRENT leg
+	n := len(state.Players)
+	target := make([]int, n)
+	remaining := make([]int, n)
+	dartsUsed := make([]int, n)
+	attempts := newLegRounds(n, attemptsToPlay)
+	best := make([]int, n)
+	resetLeg := func() {
+		for i := range target {
+			target[i] = start
+			remaining[i] = start
+			dartsUsed[i] = 0
+			best[i] = start
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+		attempts.reset()
+	}
+	resetLeg()
+
+	nextIdx := 0
+
+	g.apply = func(t *Throw, idx int) {
+		if attempts.done(idx) {
+			return
+		}
+
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		visit := 0
+		dartsUsed[idx] += t.DartsThrown
+		if rem, ok := x01Remaining(remaining[idx], t.VisitScore, t.Darts, t.CheckoutDouble, outRule); ok {
+			visit = remaining[idx] - rem
+			remaining[idx] = rem
+		}
+		scores[idx].LastVisit = &visit
+
+		switch {
+		case remaining[idx] == 0:
+			attempts.play(idx)
+			target[idx]++
+			best[idx] = max(best[idx], target[idx])
+		case dartsUsed[idx] >= checkout121Darts:
+			attempts.play(idx)
+			if state.Config.OnFail == "reset" {
+				target[idx] = start
+			}
+		}
+		if dartsUsed[idx] >= checkout121Darts || remaining[idx] == 0 {
+			// Next attempt
+			remaining[idx] = target[idx]
+			dartsUsed[idx] = 0
+		}
+
+		leg.ScoresByPlayer[t.PlayerID] = best[idx]
+
+		if attempts.settle(&match, *t, start, state.Players, best, soleHighest) {
+			resetLeg()
+		}
+		nextIdx = nextActiveIndex(n, idx, func(i int) bool { return !attempts.done(i) })
+	}
+	g.next = func() int { return nextIdx }
+
+	g.report = func() {
+		for i := range scores {
+			r := remaining[i]
+			tg := target[i]
+			b := best[i]
+			left := checkout121Darts - dartsUsed[i]
+			scores[i].Remaining = &r
+			scores[i].Target = &tg
+			scores[i].Best = &b
+			scores[i].DartsLeft = &left
+		}
+		round := attempts.current()
+		state.Round = &round
+	}
+}
@@ -0,0 +1,91 @@
+package game
+
+import "testing"
+
+func TestCheckout121ValidateConfig(t *testing.T) {
+	mode, _ := LookupMode("Checkout121")
+	tests := []struct {
+		name    string
+		config  GameConfig
+		wantErr bool
+	}{
+		{name: "defaults"},
+		{name: "lowest start", config: GameConfig{StartingScore: intPtr(2)}},
+		{name: "highest start", config: GameConfig{StartingScore: intPtr(170)}},
+		{name: "start too low", config: GameConfig{StartingScore: intPtr(1)}, wantErr: true},
+		{name: "start too high", config: GameConfig{StartingScore: intPtr(171)}, wantErr: true},
+		{name: "negative rounds", config: GameConfig{ModeOptions: ModeOptions{Rounds: -1}}, wantErr: true},
+		{name: "onFail reset", config: GameConfig{ModeOptions: ModeOptions{OnFail: "reset"}}},
+		{name: "unknown onFail", config: GameConfig{ModeOptions: ModeOptions{OnFail: "retry"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := mode.ValidateConfig(CreateGameRequest{Config: tt.config}); (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckout121Attempts(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        GameConfig
+		visits        []testVisit
+		wantTarget    int
+		wantRemaining int
+		wantDartsLeft int
+	}{
+		{name: "a visit uses darts", visits: []testVisit{v("a", "T20", "M")}, wantTarget: 121, wantRemaining: 61, wantDartsLeft: 7},
+		{name: "a checkout moves the target up", visits: []testVisit{v("a", "T20", "T19"), v("b", "M"), v("a", "D2")}, wantTarget: 122, wantRemaining: 122, wantDartsLeft: 9},
+		{
+			name: "a failed attempt stays",
+			visits: append(
+				[]testVisit{v("a", "T20", "T19"), v("b", "M"), v("a", "D2"), v("b", "M")},
+				repeat(3, v("a", "M", "M", "M"), v("b", "M"))...,
+			),
+			wantTarget:    122,
+			wantRemaining: 122,
+			wantDartsLeft: 9,
+		},
+		{
+			name:   "a failed attempt resets",
+			config: GameConfig{ModeOptions: ModeOptions{OnFail: "reset"}},
+			visits: append(
+				[]testVisit{v("a", "T20", "T19"), v("b", "M"), v("a", "D2"), v("b", "M")},
+				repeat(3, v("a", "M", "M", "M"), v("b", "M"))...,
+			),
+			wantTarget:    121,
+			wantRemaining: 121,
+			wantDartsLeft: 9,
+		},
+	}
+	for _, tt := range tests {
+		tt.config.Mode = "Checkout121"
+		state := testGame(t, tt.config, []string{"a", "b"}, tt.visits)
+		score := scoreOf(state, "a")
+		if *score.Target != tt.wantTarget || *score.Remaining != tt.wantRemaining || *score.DartsLeft != tt.wantDartsLeft {
+			t.Errorf("%s: target %d, remaining %d, darts left %d, want %d, %d, %d", tt.name, *score.Target, *score.Remaining, *score.DartsLeft, tt.wantTarget, tt.wantRemaining, tt.wantDartsLeft)
+		}
+	}
+}
+
+func TestCheckout121LegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "highest target after the attempts",
+			config:  GameConfig{Mode: "Checkout121", StartingScore: intPtr(40), ModeOptions: ModeOptions{Rounds: 1}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "D20"), v("b", "M", "M", "M"), v("b", "M", "M", "M"), v("b", "M", "M", "M")},
+			want:    "a",
+		},
+		{
+			name:    "tie played off with another attempt",
+			config:  GameConfig{Mode: "Checkout121", StartingScore: intPtr(40), ModeOptions: ModeOptions{Rounds: 1}},
+			players: []string{"a", "b"},
+			visits: []testVisit{
+				v("a", "D20"), v("b", "D20"),
+				v("a", "S1", "D20"), v("b", "M", "M", "M"), v("b", "M", "M", "M"), v("b", "M", "M", "M"),
+			},
+			want: "a",
+		},
+	})
+}
@@ -0,0 +1,256 @@
+package game
+
+// Default target sets of the marks-based modes.
+var (
+	cricketTargets = []string{"15", "16", "17", "18", "19", "20", "Bull"}
+	tacticsTargets = []string{"10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "D", "T", "Bull"}
+)
+
+// cricketMarksToClose is the number of marks needed to close a target.
+const cricketMarksToClose = 3
+
+// marksTargets returns the target set of a marks-based mode: the configured
+// targets, or the mode's default set.
+func marksTargets(config GameConfig) []target {
+	specs := config.Targets
+	if len(specs) == 0 {
+		specs = cricketTargets
+		if config.Mode == "Tactics" {
+			specs = tacticsTargets
+		}
+	}
+	targets, err := parseTargets(specs)
+	if err != nil {
+		// Validated at creation; fall back rather than fail a read.
+		targets, _ = parseTargets(cricketTargets)
+	}
+	return targets
+}
+
+// marksFor returns how many marks d puts on t. On a number (or the bull),
+// singles, doubles and trebles count 1, 2 and 3 marks; on a ring target such
+// as "any double" every hit is one mark.
+func marksFor(t target, d Dart) int {
+	if !t.Matches(d) {
+		return 0
+	}
+	if t.Multiplier != 0 {
+		return 1
+	}
+	return d.Multiplier
+}
+
+// markValue is what a scoring mark made by d on t is worth: the number for
+// number targets, the dart's own value for ring targets.
+func markValue(t target, d Dart) int {
+	if t.Segment != 0 && t.Multiplier == 0 {
+		return t.Segment
+	}
+	return d.Score()
+}
+
+func init() {
+	targets := ModeOption{Key: "targets", Type: "string[]", Description: "target set, e.g. [\"20\", \"D\", \"Bull\"]"}
+	for _, m := range []struct {
+		name, description string
+		defaults          []string
+	}{
+		{"Cricket", "Close 15-20 and the bull; score on targets your opponents have not closed.", cricketTargets},
+		{"CutThroat", "Cricket where points go to the opponents who have not closed the target; the lowest score wins.", cricketTargets},
+		{"Tactics", "Cricket on 10-20, any double, any treble and the bull.", tacticsTargets},
+	} {
+		targets.Default = m.defaults
+		RegisterMode(funcMode{
+			schema: ModeSchema{
+				Name:        m.name,
+				Description: m.description,
+				Darts:       true,
+				Teams:       true,
+				Options:     []ModeOption{targets},
+			},
+			validate: validateMarksTargets,
+			start:    startCricket,
+		})
+	}
+}
+
+// startCricket scores a match of a marks-based mode (Cricket, Cut-throat
+// Cricket, Tactics).
+//
+// Rules implemented:
+//   - The targets are the mode's target set (15-20 and the bull for
+//     Cricket, 10-20, any double, any treble and the bull for Tactics, or
+//     the configured targets)
+//   - A dart counts on one target only: the most specific one it hits that
+//     is still open for someone (D20 marks 20; it counts on "D" once every
+//     player has closed 20; the inner bull marks "Bull" before "D")
+//   - On a number, singles, doubles and trebles count 1, 2 and 3 marks
+//     (outer bull 1, inner bull 2); a ring target takes one mark per hit
+//   - Three marks close a target; further marks score its value as long as
+//     at least one opponent has not closed it
+//   - A leg is won by the first player to close every target while having
+//     at least as many points as each opponent
+//   - Legs aggregate into sets and sets into the match, as in X01
+//
+// In Cut-throat, points scored on a closed target go to every opponent who
+// has not closed it, and the leg is won with the LOWEST score instead. As
+// points land on the others, the winner need not be the player throwing:
+// after every dart, every player who has closed all targets is checked.
+func startCricket(g *play) {
+	state, scores := g.state, g.scores
+	n := len(state.Players)
+	cutThroat := state.Config.Mode == "CutThroat"
+	targets := marksTargets(state.Config)
+
+	match := newMatchScore(state, 0)
+	g.match = &match
+
+	// Per-player marks (target index -> marks) and points for the CURRENT leg
+	marks := make([][]int, n)
+	points := make([]int, n)
+	resetLeg := func() {
+		for i := range marks {
+			marks[i] = make([]int, len(targets))
+			points[i] = 0
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+	}
+	resetLeg()
+
+	closedByAllOpponents := func(idx, ti int) bool {
+		for i := range marks {
+			if i != idx && marks[i][ti] < cricketMarksToClose {
+				return false
+			}
+		}
+		return true
+	}
+
+	closedByAll := func(ti int) bool { return closedByAllOpponents(-1, ti) }
+
+	hasWonLeg := func(idx int) bool {
+		for ti := range targets {
+			if marks[idx][ti] < cricketMarksToClose {
+				return false
+			}
+		}
+		for i := range points {
+			if i == idx {
+				continue
+			}
+			if cutThroat && points[i] < points[idx] {
+				return false
+			}
+			if !cutThroat && points[i] > points[idx] {
+				return false
+			}
+		}
+		return true
+	}
+
+	// targetFor returns the one target d counts on, or -1: the most specific
+	// target it hits (see target.specificity) that is not closed by every
+	// player. Once all have closed it, a dart falls through to the next,
+	// so D20 marks 20 and only counts on "D" once 20 is dead.
+	targetFor := func(d Dart) int {
+		best := -1
+		for ti, tgt := range targets {
+			if !tgt.Matches(d) {
+				continue
+			}
+			if closedByAll(ti) {
+				continue
+			}
+			if best == -1 || tgt.specificity() > targets[best].specificity() {
+				best = ti
+			}
+		}
+		return best
+	}
+
+	// markDart applies d to target ti for the player idx and returns the
+	// points it scored.
+	markDart := func(idx, ti int, d Dart) int {
+		tgt := targets[ti]
+		hits := marksFor(tgt, d)
+
+		open := cricketMarksToClose - marks[idx][ti]
+		if open > 0 {
+			used := min(open, hits)
+			marks[idx][ti] += used
+			hits -= used
+		}
+		if hits == 0 || closedByAllOpponents(idx, ti) {
+			return 0
+		}
+
+		scored := hits * markValue(tgt, d)
+		if cutThroat {
+			for i := range marks {
+				if i != idx && marks[i][ti] < cricketMarksToClose {
+					points[i] += scored
+				}
+			}
+		} else {
+			points[idx] += scored
+		}
+		return scored
+	}
+
+	// legWinner returns who has won the leg after a dart by idx, the thrower
+	// first, or -1.
+	legWinner := func(idx int) int {
+		if hasWonLeg(idx) {
+			return idx
+		}
+		for i := range marks {
+			if i != idx && hasWonLeg(i) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	g.apply = func(t *Throw, idx int) {
+		set := &match.Sets[match.CurrentSetIndex]
+		leg := &set.Legs[match.CurrentLegIndex]
+
+		winnerIdx := -1
+		visitPoints := 0
+		for _, d := range t.Darts {
+			if ti := targetFor(d); ti != -1 {
+				visitPoints += markDart(idx, ti, d)
+			}
+
+			if winnerIdx = legWinner(idx); winnerIdx != -1 {
+				break
+			}
+		}
+
+		for i, p := range state.Players {
+			leg.ScoresByPlayer[p.ID] = points[i]
+		}
+		scores[idx].LastVisit = &visitPoints
+
+		if winnerIdx == -1 {
+			return
+		}
+
+		if !finishLeg(&match, state.Players[winnerIdx].ID, *t) {
+			startNextLegOrSet(&match, 0, state.Players)
+			resetLeg()
+		}
+	}
+
+	g.report = func() {
+		for i := range scores {
+			p := points[i]
+			scores[i].Points = &p
+			scores[i].Marks = make(map[string]int, len(targets))
+			for ti, tgt := range targets {
+				scores[i].Marks[tgt.Label] = marks[i][ti]
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package game
+
+import (
+	"maps"
+	"testing"
+)
+
+// cricketTest is a marks-mode game and the marks and points it should show.
+type cricketTest struct {
+	name       string
+	mode       string
+	targets    []string
+	players    []string
+	visits     []testVisit
+	wantMarks  map[string]int // the first player's
+	wantPoints []int
+}
+
+// testCricketScores checks the marks and points of each game.
+func testCricketScores(t *testing.T, tests []cricketTest) {
+	t.Helper()
+	for _, tt := range tests {
+		config := GameConfig{Mode: tt.mode, ModeOptions: ModeOptions{Targets: tt.targets}}
+		state := testGame(t, config, tt.players, tt.visits)
+
+		if got := state.Scores[0].Marks; !maps.Equal(got, tt.wantMarks) {
+			t.Errorf("%s: marks = %v, want %v", tt.name, got, tt.wantMarks)
+		}
+		for i, want := range tt.wantPoints {
+			if got := *state.Scores[i].Points; got != want {
+				t.Errorf("%s: %s has %d points, want %d", tt.name, tt.players[i], got, want)
+			}
+		}
+	}
+}
+
+func TestCricketScores(t *testing.T) {
+	testCricketScores(t, []cricketTest{
+		{
+			name:       "singles, doubles and trebles",
+			mode:       "Cricket",
+			targets:    []string{"20", "19", "Bull"},
+			players:    []string{"a", "b"},
+			visits:     []testVisit{v("a", "S20", "D19", "25")},
+			wantMarks:  map[string]int{"20": 1, "19": 2, "Bull": 1},
+			wantPoints: []int{0, 0},
+		},
+		{
+			name:       "inner bull",
+			mode:       "Cricket",
+			targets:    []string{"20", "Bull"},
+			players:    []string{"a", "b"},
+			visits:     []testVisit{v("a", "Bull", "Bull")},
+			wantMarks:  map[string]int{"20": 0, "Bull": 3},
+			wantPoints: []int{25, 0},
+		},
+		{
+			name:       "scores while an opponent is open",
+			mode:       "Cricket",
+			targets:    []string{"20", "19"},
+			players:    []string{"a", "b"},
+			visits:     []testVisit{v("a", "D20", "T20")},
+			wantMarks:  map[string]int{"20": 3, "19": 0},
+			wantPoints: []int{40, 0},
+		},
+		{
+			name:       "no points once every opponent has closed",
+			mode:       "Cricket",
+			targets:    []string{"20", "19"},
+			players:    []string{"a", "b"},
+			visits:     []testVisit{v("a", "T20"), v("b", "T20"), v("a", "T20")},
+			wantMarks:  map[string]int{"20": 3, "19": 0},
+			wantPoints: []int{0, 0},
+		},
+		{
+			name:       "default targets",
+			mode:       "Cricket",
+			players:    []string{"a", "b"},
+			visits:     []testVisit{v("a", "T15", "S20", "M")},
+			wantMarks:  map[string]int{"15": 3, "16": 0, "17": 0, "18": 0, "19": 0, "20": 1, "Bull": 0},
+			wantPoints: []int{0, 0},
+		},
+	})
+}
+
+func TestCricketLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "closed and level",
+			config:  GameConfig{Mode: "Cricket", ModeOptions: ModeOptions{Targets: []string{"20", "19"}}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "T20"), v("b", "M"), v("a", "T19")},
+			want:    "a",
+		},
+		{
+			name:    "closed but behind, then closed and ahead",
+			config:  GameConfig{Mode: "Cricket", ModeOptions: ModeOptions{Targets: []string{"20", "19"}}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "T20", "M", "M"), v("b", "T19", "S19", "S19"), v("a", "T19"), v("b", "T20")},
+			want:    "b",
+		},
+	})
+}
+
+func TestCutThroatScores(t *testing.T) {
+	testCricketScores(t, []cricketTest{
+		{
+			name:       "points go to the open opponents",
+			mode:       "CutThroat",
+			targets:    []string{"20", "19"},
+			players:    []string{"a", "b", "c"},
+			visits:     []testVisit{v("b", "T20"), v("a", "T20", "S20")},
+			wantMarks:  map[string]int{"20": 3, "19": 0},
+			wantPoints: []int{0, 0, 20},
+		},
+		{
+			name:       "no points once every opponent has closed",
+			mode:       "CutThroat",
+			targets:    []string{"20", "19"},
+			players:    []string{"a", "b"},
+			visits:     []testVisit{v("b", "T20"), v("a", "T20", "S20")},
+			wantMarks:  map[string]int{"20": 3, "19": 0},
+			wantPoints: []int{0, 0},
+		},
+	})
+}
+
+func TestCutThroatLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "closed but higher, then closed and lowest",
+			config:  GameConfig{Mode: "CutThroat", ModeOptions: ModeOptions{Targets: []string{"20", "19"}}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "T20", "S20"), v("b", "T20", "T19"), v("a", "T19")},
+			want:    "a",
+		},
+		{
+			name:    "won by a player who is not throwing",
+			config:  GameConfig{Mode: "CutThroat", ModeOptions: ModeOptions{Targets: []string{"20", "19"}}},
+			players: []string{"a", "b", "c"},
+			visits: []testVisit{
+				v("a", "T19", "S19", "M"), v("b", "T20", "S20", "M"), v("c", "M", "M", "M"),
+				v("a", "T20", "M", "M"), v("b", "M", "M", "M"), v("c", "T19", "S19", "M"),
+			},
+			want: "a",
+		},
+	})
+}
+
+func TestTacticsScores(t *testing.T) {
+	testCricketScores(t, []cricketTest{
+		{
+			name:       "a dart counts on one target",
+			mode:       "Tactics",
+			players:    []string{"a", "b"},
+			visits:     []testVisit{v("a", "D20", "T19", "Bull")},
+			wantMarks:  map[string]int{"10": 0, "11": 0, "12": 0, "13": 0, "14": 0, "15": 0, "16": 0, "17": 0, "18": 0, "19": 3, "20": 2, "D": 0, "T": 0, "Bull": 2},
+			wantPoints: []int{0, 0},
+		},
+		{
+			name:       "falls through to the ring once the number is dead",
+			mode:       "Tactics",
+			targets:    []string{"20", "D"},
+			players:    []string{"a", "b"},
+			visits:     []testVisit{v("a", "T20"), v("b", "T20"), v("a", "D20")},
+			wantMarks:  map[string]int{"20": 3, "D": 1},
+			wantPoints: []int{0, 0},
+		},
+		{
+			name:       "lower-case labels",
+			mode:       "Tactics",
+			targets:    []string{"20", "d", "t"},
+			players:    []string{"a", "b"},
+			visits:     []testVisit{v("a", "D1", "T2")},
+			wantMarks:  map[string]int{"20": 0, "D": 1, "T": 1},
+			wantPoints: []int{0, 0},
+		},
+	})
+}
+
+func TestTacticsLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "rings closed",
+			config:  GameConfig{Mode: "Tactics", ModeOptions: ModeOptions{Targets: []string{"D", "T"}}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "D1", "D2", "D3"), v("b", "M", "M", "M"), v("a", "T1", "T2", "T3")},
+			want:    "a",
+		},
+	})
+}
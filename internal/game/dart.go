@@ -0,0 +1,286 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BullSegment is the segment number used for the bull (25 single, 50 double).
+const BullSegment = 25
+
+// IsMiss reports whether the dart missed the scoring area.
+func (d Dart) IsMiss() bool {
+	return d.Segment == 0
+}
+
+// Score returns the points the dart is worth.
+func (d Dart) Score() int {
+	if d.IsMiss() {
+		return 0
+	}
+	return d.Segment * d.Multiplier
+}
+
+// dartMultipliers maps the S/D/T notation to multipliers.
+var dartMultipliers = map[string]int{"S": 1, "D": 2, "T": 3}
+
+// parseDart reads a dart in short notation: "T20", "D16", "S5" or "5",
+// "25" (outer bull), "Bull", "DB" or "50" (inner bull), "M" or "Miss".
+func parseDart(s string) (Dart, error) {
+	spec := strings.ToUpper(strings.TrimSpace(s))
+	switch spec {
+	case "M", "MISS", "0":
+		return Dart{}, nil
+	case "BULL", "DB", "50":
+		return Dart{Segment: BullSegment, Multiplier: 2}, nil
+	case "SB":
+		return Dart{Segment: BullSegment, Multiplier: 1}, nil
+	}
+
+	multiplier := 1
+	if m, ok := dartMultipliers[spec[:min(1, len(spec))]]; ok {
+		multiplier = m
+		spec = spec[1:]
+	}
+	n, err := strconv.Atoi(spec)
+	if err != nil || n < 1 || (n > 20 && n != BullSegment) {
+		return Dart{}, fmt.Errorf("invalid dart %q", s)
+	}
+	return Dart{Segment: n, Multiplier: multiplier}, nil
+}
+
+// UnmarshalJSON accepts a dart as an object whose multiplier is a number or
+// "S", "D" or "T" ({"segment": 20, "multiplier": "T"}), or as a string in
+// short notation (see parseDart).
+func (d *Dart) UnmarshalJSON(b []byte) error {
+	var notation string
+	if err := json.Unmarshal(b, &notation); err == nil {
+		dart, err := parseDart(notation)
+		if err != nil {
+			return err
+		}
+		*d = dart
+		return nil
+	}
+
+	var raw struct {
+		Segment    int             `json:"segment"`
+		Multiplier json.RawMessage `json:"multiplier"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*d = Dart{Segment: raw.Segment}
+	if len(raw.Multiplier) == 0 || string(raw.Multiplier) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw.Multiplier, &d.Multiplier); err == nil {
+		return nil
+	}
+	var letter string
+	if err := json.Unmarshal(raw.Multiplier, &letter); err != nil {
+		return fmt.Errorf("invalid dart multiplier %s", raw.Multiplier)
+	}
+	m, ok := dartMultipliers[strings.ToUpper(strings.TrimSpace(letter))]
+	if !ok {
+		return fmt.Errorf("invalid dart multiplier %q", letter)
+	}
+	d.Multiplier = m
+	return nil
+}
+
+// dartScores returns the points of each dart, or nil without darts.
+func dartScores(darts []Dart) []int {
+	if len(darts) == 0 {
+		return nil
+	}
+	points := make([]int, len(darts))
+	for i, d := range darts {
+		points[i] = d.Score()
+	}
+	return points
+}
+
+// Allows reports whether d may open or finish a leg under the rule.
+func (rule CheckRule) Allows(d Dart) bool {
+	if d.IsMiss() {
+		return false
+	}
+	switch rule {
+	case RuleDouble:
+		return d.Multiplier == 2
+	case RuleMaster:
+		return d.Multiplier == 2 || d.Multiplier == 3
+	default:
+		return true
+	}
+}
+
+// valid reports whether rule is one of the known rules.
+func (rule CheckRule) valid() bool {
+	switch rule {
+	case RuleStraight, RuleDouble, RuleMaster:
+		return true
+	}
+	return false
+}
+
+// legacyCheckRule applies a legacy doubleIn/doubleOut flag to rule: an
+// empty rule takes the flag's rule, a rule that says otherwise is an error.
+func legacyCheckRule(rule *CheckRule, flag *bool, flagName, ruleName string) error {
+	if flag == nil {
+		return nil
+	}
+	want := RuleStraight
+	if *flag {
+		want = RuleDouble
+	}
+	if *rule == "" {
+		*rule = want
+		return nil
+	}
+	if *rule != want {
+		return fmt.Errorf("%s %t disagrees with %s %q", flagName, *flag, ruleName, *rule)
+	}
+	return nil
+}
+
+// fillLegacyCheckFlags sets the legacy doubleIn/doubleOut flags of a loaded
+// game from its rules, so clients that read them still see them.
+func fillLegacyCheckFlags(config *GameConfig) {
+	doubleIn := config.InRule == RuleDouble
+	doubleOut := config.OutRule == RuleDouble
+	config.DoubleIn = &doubleIn
+	config.DoubleOut = &doubleOut
+}
+
+// openingScore returns what a visit scores for a player who has not yet
+// opened under a double or master in-rule, and whether the visit opened
+// them.
+//
+// With per-dart data only darts from the opening dart onward count. With a
+// visit total only, the total is taken as already counted from the opening
+// dart, so any non-zero visit opens the player.
+func openingScore(t Throw, rule CheckRule) (int, []Dart, bool) {
+	if len(t.Darts) == 0 {
+		return t.VisitScore, nil, t.VisitScore > 0
+	}
+	for i, d := range t.Darts {
+		if !rule.Allows(d) {
+			continue
+		}
+		score := 0
+		for _, rest := range t.Darts[i:] {
+			score += rest.Score()
+		}
+		return score, t.Darts[i:], true
+	}
+	return 0, nil, false
+}
+
+// finishRule is the out-rule of an X01-style game.
+type finishRule struct {
+	rule   CheckRule
+	noBull bool // the inner bull does not count as a double (noBullFinish)
+}
+
+// allows reports whether d may finish a leg.
+func (f finishRule) allows(d Dart) bool {
+	if f.noBull && f.rule != RuleStraight && d.Segment == BullSegment {
+		return false
+	}
+	return f.rule.Allows(d)
+}
+
+// x01Remaining applies a visit to the current remaining score under an
+// out-rule. It returns the new remaining score, or false for a bust.
+//
+// With per-dart data the darts are applied one by one, so the dart that
+// reaches zero must satisfy the out-rule and a leftover that cannot be
+// finished (1 under a double or master out) busts immediately. With a visit
+// total only, the finishing dart is checked when the visit names it
+// (checkoutDouble, a segment whose double finished); otherwise the
+// checkout is taken on trust. A checkoutDouble on a visit that does not
+// reach zero is bad input (AddThrow rejects it) and counts as a bust.
+func x01Remaining(cur, visitScore int, darts []Dart, checkoutDouble *int, out finishRule) (int, bool) {
+	unfinishable := func(rem int) bool {
+		return rem < 0 || (out.rule != RuleStraight && rem == 1)
+	}
+
+	if len(darts) == 0 {
+		rem := cur - visitScore
+		if checkoutDouble != nil {
+			if rem != 0 {
+				return cur, false
+			}
+			return 0, out.allows(Dart{Segment: *checkoutDouble, Multiplier: 2})
+		}
+		return rem, !unfinishable(rem)
+	}
+
+	rem := cur
+	for _, d := range darts {
+		rem -= d.Score()
+		if unfinishable(rem) {
+			return cur, false
+		}
+		if rem == 0 {
+			return 0, out.allows(d)
+		}
+	}
+	return rem, true
+}
+
+// finishingDart returns the index of the dart that brings cur down to
+// exactly 0, or -1 if none does. Whether it is a valid finish is up to the
+// out-rule; either way the visit ends with it.
+func finishingDart(cur int, darts []Dart) int {
+	for i, d := range darts {
+		cur -= d.Score()
+		if cur == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateCheckoutDouble checks the finishing double n named with a visit
+// total: it has to be a double (or the bull) that fits in the visit.
+func validateCheckoutDouble(req CreateThrowRequest, n int) error {
+	if len(req.Darts) > 0 {
+		return errors.New("checkoutDouble is only for visit-total input; the darts already show the finish")
+	}
+	if n < 1 || (n > 20 && n != BullSegment) {
+		return errors.New("checkoutDouble must be 1-20 or 25")
+	}
+	rest := req.VisitScore - 2*n
+	if rest < 0 || rest > 60*(req.DartsThrown-1) {
+		return fmt.Errorf("a visit of %d in %d darts cannot finish on D%d", req.VisitScore, req.DartsThrown, n)
+	}
+	return nil
+}
+
+// validateDarts checks the per-dart input of a visit.
+func validateDarts(darts []Dart) error {
+	if len(darts) > 3 {
+		return errors.New("a visit has at most 3 darts")
+	}
+	for i, d := range darts {
+		if d.IsMiss() {
+			continue
+		}
+		if d.Segment < 0 || (d.Segment > 20 && d.Segment != BullSegment) {
+			return fmt.Errorf("dart %d: segment must be 1-20 or 25", i+1)
+		}
+		if d.Multiplier < 1 || d.Multiplier > 3 {
+			return fmt.Errorf("dart %d: multiplier must be 1, 2 or 3", i+1)
+		}
+		if d.Segment == BullSegment && d.Multiplier == 3 {
+			return fmt.Errorf("dart %d: there is no treble bull", i+1)
+		}
+	}
+	return nil
+}
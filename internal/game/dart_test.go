@@ -0,0 +1,214 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDart(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Dart
+		wantErr bool
+	}{
+		{in: "T20", want: Dart{Segment: 20, Multiplier: 3}},
+		{in: "d16", want: Dart{Segment: 16, Multiplier: 2}},
+		{in: "S5", want: Dart{Segment: 5, Multiplier: 1}},
+		{in: " 5 ", want: Dart{Segment: 5, Multiplier: 1}},
+		{in: "25", want: Dart{Segment: BullSegment, Multiplier: 1}},
+		{in: "SB", want: Dart{Segment: BullSegment, Multiplier: 1}},
+		{in: "Bull", want: Dart{Segment: BullSegment, Multiplier: 2}},
+		{in: "DB", want: Dart{Segment: BullSegment, Multiplier: 2}},
+		{in: "50", want: Dart{Segment: BullSegment, Multiplier: 2}},
+		{in: "M", want: Dart{}},
+		{in: "miss", want: Dart{}},
+		{in: "0", want: Dart{}},
+		{in: "", wantErr: true},
+		{in: "D", wantErr: true},
+		{in: "T21", wantErr: true},
+		{in: "26", wantErr: true},
+		{in: "X5", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseDart(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDart(%q) error = %v, wantErr %t", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDart(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDartUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Dart
+		wantErr bool
+	}{
+		{in: `"T20"`, want: Dart{Segment: 20, Multiplier: 3}},
+		{in: `"Bull"`, want: Dart{Segment: BullSegment, Multiplier: 2}},
+		{in: `{"segment": 20, "multiplier": 3}`, want: Dart{Segment: 20, Multiplier: 3}},
+		{in: `{"segment": 19, "multiplier": "d"}`, want: Dart{Segment: 19, Multiplier: 2}},
+		{in: `{"segment": 5, "multiplier": null}`, want: Dart{Segment: 5}},
+		{in: `{"segment": 0}`, want: Dart{}},
+		{in: `"X"`, wantErr: true},
+		{in: `{"segment": 20, "multiplier": "Q"}`, wantErr: true},
+		{in: `{"segment": 20, "multiplier": true}`, wantErr: true},
+		{in: `[20, 3]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var got Dart
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("unmarshal %s error = %v, wantErr %t", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("unmarshal %s = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLegacyCheckRule(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name    string
+		rule    CheckRule
+		flag    *bool
+		want    CheckRule
+		wantErr bool
+	}{
+		{name: "no flag", rule: RuleMaster, want: RuleMaster},
+		{name: "no flag, no rule", want: ""},
+		{name: "true fills double", flag: &yes, want: RuleDouble},
+		{name: "false fills straight", flag: &no, want: RuleStraight},
+		{name: "agrees", rule: RuleDouble, flag: &yes, want: RuleDouble},
+		{name: "disagrees", rule: RuleMaster, flag: &yes, wantErr: true},
+	}
+	for _, tt := range tests {
+		rule := tt.rule
+		err := legacyCheckRule(&rule, tt.flag, "doubleOut", "outRule")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %t", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && rule != tt.want {
+			t.Errorf("%s: rule = %q, want %q", tt.name, rule, tt.want)
+		}
+	}
+}
+
+func TestFillLegacyCheckFlags(t *testing.T) {
+	tests := []struct {
+		in, out                     CheckRule
+		wantDoubleIn, wantDoubleOut bool
+	}{
+		{in: RuleStraight, out: RuleDouble, wantDoubleOut: true},
+		{in: RuleDouble, out: RuleStraight, wantDoubleIn: true},
+		{in: RuleStraight, out: RuleMaster},
+	}
+	for _, tt := range tests {
+		config := GameConfig{InRule: tt.in, OutRule: tt.out}
+		fillLegacyCheckFlags(&config)
+		if *config.DoubleIn != tt.wantDoubleIn || *config.DoubleOut != tt.wantDoubleOut {
+			t.Errorf("%s/%s: doubleIn %t, doubleOut %t, want %t, %t", tt.in, tt.out, *config.DoubleIn, *config.DoubleOut, tt.wantDoubleIn, tt.wantDoubleOut)
+		}
+	}
+}
+
+func TestX01Remaining(t *testing.T) {
+	double := finishRule{rule: RuleDouble}
+	tests := []struct {
+		name           string
+		cur, visit     int
+		darts          []string
+		checkoutDouble int // 0 = none
+		out            finishRule
+		want           int // the remaining score, when not a bust
+		wantOK         bool
+	}{
+		{name: "total", cur: 501, visit: 60, out: double, want: 441, wantOK: true},
+		{name: "total over", cur: 40, visit: 60, out: double},
+		{name: "total leaves 1", cur: 41, visit: 40, out: double},
+		{name: "total leaves 1 straight-out", cur: 41, visit: 40, out: finishRule{rule: RuleStraight}, want: 1, wantOK: true},
+		{name: "total checkout on trust", cur: 40, visit: 40, out: double, want: 0, wantOK: true},
+		{name: "checkoutDouble", cur: 100, visit: 100, checkoutDouble: 20, out: double, want: 0, wantOK: true},
+		{name: "checkoutDouble bull", cur: 50, visit: 50, checkoutDouble: 25, out: double, want: 0, wantOK: true},
+		{name: "checkoutDouble bull noBull", cur: 50, visit: 50, checkoutDouble: 25, out: finishRule{rule: RuleDouble, noBull: true}},
+		{name: "checkoutDouble no finish", cur: 100, visit: 60, checkoutDouble: 20, out: double},
+		{name: "darts", cur: 501, darts: []string{"T20", "T20", "T20"}, out: double, want: 321, wantOK: true},
+		{name: "darts over", cur: 50, darts: []string{"T20"}, out: double},
+		{name: "darts leave 1", cur: 21, darts: []string{"S20", "D1"}, out: double},
+		{name: "darts single finish", cur: 20, darts: []string{"S20"}, out: double},
+		{name: "darts single finish straight-out", cur: 20, darts: []string{"S20"}, out: finishRule{rule: RuleStraight}, want: 0, wantOK: true},
+		{name: "darts double finish", cur: 40, darts: []string{"D20", "T20"}, out: double, want: 0, wantOK: true},
+		{name: "darts treble finish", cur: 60, darts: []string{"T20"}, out: double},
+		{name: "darts treble finish master-out", cur: 60, darts: []string{"T20"}, out: finishRule{rule: RuleMaster}, want: 0, wantOK: true},
+		{name: "darts bull finish", cur: 50, darts: []string{"Bull"}, out: double, want: 0, wantOK: true},
+		{name: "darts bull finish noBull", cur: 50, darts: []string{"Bull"}, out: finishRule{rule: RuleDouble, noBull: true}},
+		{name: "darts bull finish noBull straight-out", cur: 50, darts: []string{"Bull"}, out: finishRule{rule: RuleStraight, noBull: true}, want: 0, wantOK: true},
+	}
+	for _, tt := range tests {
+		var checkoutDouble *int
+		if tt.checkoutDouble != 0 {
+			checkoutDouble = &tt.checkoutDouble
+		}
+		got, ok := x01Remaining(tt.cur, tt.visit, testDarts(t, tt.darts...), checkoutDouble, tt.out)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("%s: x01Remaining = %d, %t, want %d, %t", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestValidateFinishingDart(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  GameConfig
+		visits  []testVisit
+		darts   []string
+		wantErr bool
+	}{
+		{name: "finish on the last dart", config: GameConfig{Mode: "X01", StartingScore: intPtr(40)}, darts: []string{"S20", "D10"}},
+		{name: "darts after the finish", config: GameConfig{Mode: "X01", StartingScore: intPtr(40)}, darts: []string{"D20", "T20", "T20"}, wantErr: true},
+		{name: "darts after a bust on 0", config: GameConfig{Mode: "X01", StartingScore: intPtr(40)}, darts: []string{"S20", "S20", "M"}, wantErr: true},
+		{name: "over and on", config: GameConfig{Mode: "X01", StartingScore: intPtr(40)}, darts: []string{"T20", "D10", "M"}},
+		{name: "visit total", config: GameConfig{Mode: "X01", StartingScore: intPtr(40)}},
+		{name: "before the opening dart", config: GameConfig{Mode: "X01", StartingScore: intPtr(40), InRule: RuleDouble}, darts: []string{"S20", "S20", "D20"}},
+		{name: "after the opening dart", config: GameConfig{Mode: "X01", StartingScore: intPtr(40), InRule: RuleDouble}, darts: []string{"D10", "D10", "M"}, wantErr: true},
+		{name: "checkout121", config: GameConfig{Mode: "Checkout121", StartingScore: intPtr(40)}, darts: []string{"D20", "M"}, wantErr: true},
+		{name: "counting up", config: GameConfig{Mode: "HighScore"}, darts: []string{"D20", "M"}},
+	}
+	for _, tt := range tests {
+		state := testGame(t, tt.config, []string{"a", "b"}, tt.visits)
+		req := CreateThrowRequest{PlayerID: "a", Darts: testDarts(t, tt.darts...), VisitScore: 40}
+		err := validateFinishingDart(state, req)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateCheckoutDouble(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreateThrowRequest
+		n       int
+		wantErr bool
+	}{
+		{name: "fits", req: CreateThrowRequest{VisitScore: 100, DartsThrown: 3}, n: 20},
+		{name: "one dart", req: CreateThrowRequest{VisitScore: 40, DartsThrown: 1}, n: 20},
+		{name: "bull", req: CreateThrowRequest{VisitScore: 170, DartsThrown: 3}, n: 25},
+		{name: "not a double", req: CreateThrowRequest{VisitScore: 40, DartsThrown: 1}, n: 21, wantErr: true},
+		{name: "more than the visit", req: CreateThrowRequest{VisitScore: 30, DartsThrown: 3}, n: 20, wantErr: true},
+		{name: "too much for the other darts", req: CreateThrowRequest{VisitScore: 100, DartsThrown: 1}, n: 20, wantErr: true},
+		{name: "with darts", req: CreateThrowRequest{VisitScore: 40, DartsThrown: 1, Darts: []Dart{{Segment: 20, Multiplier: 2}}}, n: 20, wantErr: true},
+	}
+	for _, tt := range tests {
+		err := validateCheckoutDouble(tt.req, tt.n)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: validateCheckoutDouble error = %v, wantErr %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package game
+
+// valid reports whether f is one of the known formats.
+func (f Format) valid() bool {
+	switch f {
+	case FormatFirstTo, FormatBestOf, FormatFixed:
+		return true
+	}
+	return false
+}
+
+// limits turns the configured count n (legs per set or sets per match) into
+// the number of wins that decides it and the most that can be played; 0
+// means no limit of that kind.
+func (f Format) limits(n int) (toWin, toPlay int) {
+	if n <= 0 {
+		n = 1
+	}
+	switch f {
+	case FormatBestOf:
+		return n/2 + 1, n
+	case FormatFixed:
+		return 0, n
+	default:
+		return n, 0
+	}
+}
+
+// decide settles a set or match from the wins so far (id -> won) after
+// played legs or sets. It reports whether it is over, and its winner; an
+// over result without a winner is a draw.
+func decide(won map[string]int, played, toWin, toPlay int) (winner *string, over bool) {
+	if toWin > 0 {
+		for id, n := range won {
+			if n >= toWin {
+				id := id
+				return &id, true
+			}
+		}
+	}
+	if toPlay == 0 || played < toPlay {
+		return nil, false
+	}
+
+	// All played: the sole most wins takes it, otherwise it is drawn.
+	best, tied := "", false
+	for id, n := range won {
+		switch {
+		case best == "" || n > won[best]:
+			best, tied = id, false
+		case n == won[best]:
+			tied = true
+		}
+	}
+	if best == "" || tied {
+		return nil, true
+	}
+	return &best, true
+}
+
+// decidingSet reports whether the current set decides the match: the only
+// set, or one that at least two sides need to win it.
+func decidingSet(match *MatchScore) bool {
+	if match.SetsToWin <= 1 {
+		return match.SetsToWin == 1
+	}
+	needing := 0
+	for _, n := range computeSetsWon(match) {
+		if n == match.SetsToWin-1 {
+			needing++
+		}
+	}
+	return needing >= 2
+}
+
+// decideTiebreak settles a deciding set under tb from the legs won so far
+// (id -> won), and flags whether its tiebreak or sudden death is in play.
+func decideTiebreak(tb Tiebreak, set *SetScore, won map[string]int) (winner *string, over bool) {
+	winBy := tb.WinBy
+	if winBy <= 0 {
+		winBy = 2
+	}
+
+	leader, lead, second := tiebreakStanding(won)
+	flagTiebreak(tb, set, won)
+
+	if lead < set.LegsToWin || lead == second {
+		return nil, false
+	}
+	if lead-second >= winBy || (tb.Cap > 0 && lead >= tb.Cap) {
+		return &leader, true
+	}
+	return nil, false
+}
+
+// flagTiebreak sets whether the tiebreak (both leaders within a leg of the
+// legs to win) and sudden death (both one leg short of the cap) are in play
+// in a deciding set, from the legs won so far.
+func flagTiebreak(tb Tiebreak, set *SetScore, won map[string]int) {
+	_, _, second := tiebreakStanding(won)
+	set.Tiebreak = second >= set.LegsToWin-1
+	set.SuddenDeath = tb.Cap > 0 && second == tb.Cap-1
+}
+
+// markDecidingSet flags the match's current set, when it has just started,
+// as played under the tiebreak if it decides the match. Sets won only
+// change when a set ends, so the flag holds for the whole set.
+func markDecidingSet(match *MatchScore) {
+	set := &match.Sets[match.CurrentSetIndex]
+	set.Deciding = match.Tiebreak != nil && decidingSet(match)
+}
+
+// tiebreakStanding returns the leader of a set by legs won (id -> won),
+// their legs and the runner-up's.
+func tiebreakStanding(won map[string]int) (leader string, lead, second int) {
+	for id, n := range won {
+		switch {
+		case n > lead:
+			leader, lead, second = id, n, lead
+		case n > second:
+			second = n
+		}
+	}
+	return leader, lead, second
+}
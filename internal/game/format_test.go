@@ -0,0 +1,143 @@
+package game
+
+import "testing"
+
+func TestFormatLimits(t *testing.T) {
+	tests := []struct {
+		format        Format
+		n             int
+		toWin, toPlay int
+	}{
+		{format: FormatFirstTo, n: 3, toWin: 3},
+		{format: "", n: 2, toWin: 2},
+		{format: FormatBestOf, n: 5, toWin: 3, toPlay: 5},
+		{format: FormatBestOf, n: 4, toWin: 3, toPlay: 4},
+		{format: FormatFixed, n: 4, toPlay: 4},
+		{format: FormatFirstTo, n: 0, toWin: 1},
+	}
+	for _, tt := range tests {
+		toWin, toPlay := tt.format.limits(tt.n)
+		if toWin != tt.toWin || toPlay != tt.toPlay {
+			t.Errorf("%q.limits(%d) = %d, %d, want %d, %d", tt.format, tt.n, toWin, toPlay, tt.toWin, tt.toPlay)
+		}
+	}
+}
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name          string
+		won           map[string]int
+		played        int
+		toWin, toPlay int
+		want          string // "" = no winner
+		wantOver      bool
+	}{
+		{name: "first to, won", won: map[string]int{"a": 2, "b": 1}, played: 3, toWin: 2, want: "a", wantOver: true},
+		{name: "first to, open", won: map[string]int{"a": 1, "b": 1}, played: 2, toWin: 2},
+		{name: "best of, won early", won: map[string]int{"a": 0, "b": 3}, played: 3, toWin: 3, toPlay: 5, want: "b", wantOver: true},
+		{name: "best of, open", won: map[string]int{"a": 2, "b": 1}, played: 3, toWin: 3, toPlay: 5},
+		{name: "fixed, open", won: map[string]int{"a": 2, "b": 0}, played: 2, toPlay: 3},
+		{name: "fixed, most wins", won: map[string]int{"a": 2, "b": 1}, played: 3, toPlay: 3, want: "a", wantOver: true},
+		{name: "fixed, drawn", won: map[string]int{"a": 1, "b": 1}, played: 2, toPlay: 2, wantOver: true},
+		{name: "fixed, nothing won", won: map[string]int{}, played: 2, toPlay: 2, wantOver: true},
+	}
+	for _, tt := range tests {
+		winner, over := decide(tt.won, tt.played, tt.toWin, tt.toPlay)
+		if got := deref(winner); got != tt.want || over != tt.wantOver {
+			t.Errorf("%s: decide = %q, %t, want %q, %t", tt.name, got, over, tt.want, tt.wantOver)
+		}
+	}
+}
+
+func TestMatchFormats(t *testing.T) {
+	// Each visit of 40 from 40 checks out a leg (straight out).
+	checkouts := func(players ...string) []testVisit {
+		var visits []testVisit
+		for _, p := range players {
+			visits = append(visits, vt(p, 40))
+		}
+		return visits
+	}
+	tests := []struct {
+		name         string
+		config       GameConfig
+		visits       []testVisit
+		wantFinished bool
+		wantWinner   string
+	}{
+		{name: "first to 2 legs, open", config: GameConfig{Legs: 2}, visits: checkouts("a", "b"), wantFinished: false},
+		{name: "first to 2 legs", config: GameConfig{Legs: 2}, visits: checkouts("a", "b", "a"), wantFinished: true, wantWinner: "a"},
+		{name: "best of 3 legs", config: GameConfig{Format: FormatBestOf, Legs: 3}, visits: checkouts("b", "b"), wantFinished: true, wantWinner: "b"},
+		{name: "fixed 2 legs drawn", config: GameConfig{Format: FormatFixed, Legs: 2}, visits: checkouts("a", "b"), wantFinished: true},
+		{name: "fixed 3 legs", config: GameConfig{Format: FormatFixed, Legs: 3}, visits: checkouts("a", "a", "b"), wantFinished: true, wantWinner: "a"},
+		{name: "best of 3 sets", config: GameConfig{Format: FormatBestOf, Legs: 1, Sets: 3}, visits: checkouts("a", "b", "b"), wantFinished: true, wantWinner: "b"},
+	}
+	for _, tt := range tests {
+		tt.config.Mode = "X01"
+		tt.config.StartingScore = intPtr(40)
+		tt.config.OutRule = RuleStraight
+		state := testGame(t, tt.config, []string{"a", "b"}, tt.visits)
+
+		finished := state.MatchScore.FinishedAt != nil
+		if finished != tt.wantFinished || deref(state.WinnerID) != tt.wantWinner {
+			t.Errorf("%s: finished %t, won by %q, want %t, %q", tt.name, finished, deref(state.WinnerID), tt.wantFinished, tt.wantWinner)
+		}
+	}
+}
+
+func TestDecideTiebreak(t *testing.T) {
+	tests := []struct {
+		name            string
+		tb              Tiebreak
+		won             map[string]int
+		want            string // "" = no winner
+		wantOver        bool
+		wantTiebreak    bool
+		wantSuddenDeath bool
+	}{
+		{name: "short of the legs to win", won: map[string]int{"a": 2, "b": 0}},
+		{name: "clear at the legs to win", won: map[string]int{"a": 3, "b": 1}, want: "a", wantOver: true},
+		{name: "in the tiebreak", won: map[string]int{"a": 2, "b": 2}, wantTiebreak: true},
+		{name: "one clear", won: map[string]int{"a": 3, "b": 2}, wantTiebreak: true},
+		{name: "two clear", won: map[string]int{"a": 4, "b": 2}, want: "a", wantOver: true, wantTiebreak: true},
+		{name: "win by one", tb: Tiebreak{WinBy: 1}, won: map[string]int{"a": 2, "b": 3}, want: "b", wantOver: true, wantTiebreak: true},
+		{name: "sudden death", tb: Tiebreak{Cap: 5}, won: map[string]int{"a": 4, "b": 4}, wantTiebreak: true, wantSuddenDeath: true},
+		{name: "cap", tb: Tiebreak{Cap: 5}, won: map[string]int{"a": 4, "b": 5}, want: "b", wantOver: true, wantTiebreak: true, wantSuddenDeath: true},
+	}
+	for _, tt := range tests {
+		set := SetScore{LegsToWin: 3}
+		winner, over := decideTiebreak(tt.tb, &set, tt.won)
+		if got := deref(winner); got != tt.want || over != tt.wantOver {
+			t.Errorf("%s: decideTiebreak = %q, %t, want %q, %t", tt.name, got, over, tt.want, tt.wantOver)
+		}
+		if set.Tiebreak != tt.wantTiebreak || set.SuddenDeath != tt.wantSuddenDeath {
+			t.Errorf("%s: tiebreak, sudden death = %t, %t, want %t, %t", tt.name, set.Tiebreak, set.SuddenDeath, tt.wantTiebreak, tt.wantSuddenDeath)
+		}
+	}
+}
+
+func TestDecidingSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		sets     int
+		visits   []testVisit
+		wantSet  int // the current set's number
+		deciding bool
+	}{
+		{name: "the only set", sets: 1, wantSet: 1, deciding: true},
+		{name: "first of three sets", sets: 2, wantSet: 1},
+		{name: "second set, one leader", sets: 2, visits: repeat(2, vt("a", 40)), wantSet: 2},
+		{name: "third set, both need it", sets: 2, visits: []testVisit{vt("a", 40), vt("a", 40), vt("a", 0), vt("b", 40), vt("b", 40)}, wantSet: 3, deciding: true},
+	}
+	for _, tt := range tests {
+		config := GameConfig{Mode: "X01", StartingScore: intPtr(40), OutRule: RuleStraight, Legs: 2, Sets: tt.sets, Tiebreak: &Tiebreak{}, Starter: StarterWinner}
+		state := testGame(t, config, []string{"a", "b"}, tt.visits)
+		set := state.MatchScore.Sets[state.MatchScore.CurrentSetIndex]
+		if set.SetNumber != tt.wantSet || set.Deciding != tt.deciding {
+			t.Errorf("%s: set %d deciding %t, want set %d deciding %t", tt.name, set.SetNumber, set.Deciding, tt.wantSet, tt.deciding)
+		}
+		if set.Tiebreak {
+			t.Errorf("%s: tiebreak in play before a leg is won", tt.name)
+		}
+	}
+}
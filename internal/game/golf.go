@@ -0,0 +1,126 @@
+package game
+
+import (
+	"errors"
+	"strconv"
+)
+
+// defaultGolfHoles is the course length when the config does not set holes.
+const defaultGolfHoles = 9
+
+// golfMissStrokes is what a hole costs when the counting dart misses it.
+const golfMissStrokes = 5
+
+// golfStrokes returns the strokes a dart scores on a hole.
+func golfStrokes(d Dart, hole int) int {
+	if d.IsMiss() || d.Segment != hole {
+		return golfMissStrokes
+	}
+	switch d.Multiplier {
+	case 3:
+		return 1
+	case 2:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "Golf",
+			Description: "Hole N targets number N; the last dart of the visit gives the strokes, lowest total wins.",
+			Darts:       true,
+			Teams:       true,
+			Options: []ModeOption{
+				{Key: "holes", Type: "int", Default: defaultGolfHoles, Values: []any{9, 18}, Description: "course length"},
+			},
+		},
+		validate: func(req CreateGameRequest) error {
+			if req.Config.Holes != 0 && req.Config.Holes != 9 && req.Config.Holes != 18 {
+				return errors.New("holes must be 9 or 18")
+			}
+			return nil
+		},
+		start: startGolf,
+	})
+}
+
+// startGolf scores a Golf match.
+//
+// Rules implemented:
+//   - Hole N targets number N; every player plays one visit per hole
+//   - A player may stop after any dart: the last dart of the visit counts,
+//     scoring a treble 1 stroke, a double 2, a single 3 and anything else 5
+//   - The lowest total after 9 or 18 holes wins the leg; a tie is played
+//     off hole by hole until one player leads
+//   - RoundScore rows hold the strokes per hole for every player
+func startGolf(g *play) {
+	state, scores := g.state, g.scores
+	holes := state.Config.Holes
+	if holes <= 0 {
+		holes = defaultGolfHoles
+	}
+
+	match := newMatchScore(state, 0)
+	g.match = &match
+
+	// Per-player strokes and holes played, plus the card, for the CURRENT leg
+	strokes := make([]int, len(state.Players))
+	holesPlayed := newLegRounds(len(state.Players), holes)
+	var card []RoundScore
+	resetLeg := func() {
+		for i := range strokes {
+			strokes[i] = 0
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+		card = make([]RoundScore, 0, holes)
+		holesPlayed.reset()
+	}
+	resetLeg()
+
+	g.apply = func(t *Throw, idx int) {
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		hole := holesPlayed.play(idx)
+		number := numberForRound(hole)
+
+		holeStrokes := golfMissStrokes
+		if len(t.Darts) > 0 {
+			holeStrokes = golfStrokes(t.Darts[len(t.Darts)-1], number)
+		}
+		strokes[idx] += holeStrokes
+
+		if len(card) < hole {
+			card = append(card, RoundScore{
+				Round:          hole,
+				Target:         strconv.Itoa(number),
+				ScoresByPlayer: make(map[string]int, len(state.Players)),
+				TotalsByPlayer: make(map[string]int, len(state.Players)),
+			})
+		}
+		card[hole-1].ScoresByPlayer[t.PlayerID] = holeStrokes
+		card[hole-1].TotalsByPlayer[t.PlayerID] = strokes[idx]
+
+		leg.ScoresByPlayer[t.PlayerID] = strokes[idx]
+		scores[idx].LastVisit = &holeStrokes
+
+		if holesPlayed.settle(&match, *t, 0, state.Players, strokes, soleLowest) {
+			resetLeg()
+		}
+	}
+
+	g.report = func() {
+		for i := range scores {
+			s := strokes[i]
+			scores[i].Points = &s
+			target := numberForRound(holesPlayed.next(i))
+			scores[i].Target = &target
+		}
+		hole := holesPlayed.current()
+		state.Round = &hole
+		state.Rounds = card
+	}
+}
@@ -0,0 +1,55 @@
+package game
+
+import "testing"
+
+func TestGolfStrokes(t *testing.T) {
+	tests := []struct {
+		dart string
+		hole int
+		want int
+	}{
+		{dart: "T3", hole: 3, want: 1},
+		{dart: "D3", hole: 3, want: 2},
+		{dart: "S3", hole: 3, want: 3},
+		{dart: "T4", hole: 3, want: golfMissStrokes},
+		{dart: "M", hole: 3, want: golfMissStrokes},
+	}
+	for _, tt := range tests {
+		if got := golfStrokes(testDarts(t, tt.dart)[0], tt.hole); got != tt.want {
+			t.Errorf("golfStrokes(%s, %d) = %d, want %d", tt.dart, tt.hole, got, tt.want)
+		}
+	}
+}
+
+func TestGolfLastDartCounts(t *testing.T) {
+	state := testGame(t, GameConfig{Mode: "Golf"}, []string{"a", "b"}, []testVisit{v("a", "T1", "S1")})
+	if got := *scoreOf(state, "a").Points; got != 3 {
+		t.Errorf("%d strokes, want 3", got)
+	}
+}
+
+func TestGolfLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "lowest after nine holes",
+			config:  GameConfig{Mode: "Golf"},
+			players: []string{"a", "b"},
+			visits:  append([]testVisit{v("a", "T1"), v("b", "S1")}, repeat(8, v("a", "M"), v("b", "M"))...),
+			want:    "a",
+		},
+		{
+			name:    "eighteen holes",
+			config:  GameConfig{Mode: "Golf", ModeOptions: ModeOptions{Holes: 18}},
+			players: []string{"a", "b"},
+			visits:  append(repeat(17, v("a", "M"), v("b", "M")), v("a", "M"), v("b", "D18")),
+			want:    "b",
+		},
+		{
+			name:    "tie played off",
+			config:  GameConfig{Mode: "Golf"},
+			players: []string{"a", "b"},
+			visits:  append(repeat(9, v("a", "M"), v("b", "M")), v("a", "S10"), v("b", "D10")),
+			want:    "b",
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package game
+
+import "errors"
+
+// defaultGotchaTarget is the score to reach when the config does not set a
+// starting score.
+const defaultGotchaTarget = 301
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "Gotcha",
+			Description: "Count up to the target exactly; landing on an opponent's score knocks them back to zero.",
+			Teams:       true,
+			Options: []ModeOption{
+				{Key: "startingScore", Type: "int", Default: defaultGotchaTarget, Description: "target to reach, 1 or more"},
+			},
+		},
+		validate: func(req CreateGameRequest) error {
+			// A target of 0 is reached by any empty visit, and a
+			// negative one never.
+			if s := req.Config.StartingScore; s != nil && *s < 1 {
+				return errors.New("startingScore must be > 0")
+			}
+			return nil
+		},
+		start: startGotcha,
+	})
+}
+
+// startGotcha scores a Gotcha match.
+//
+// Rules implemented:
+//   - Everyone counts up from zero towards the target (startingScore,
+//     default 301), which has to be hit exactly; going over is a bust
+//   - Landing exactly on an opponent's current score knocks that opponent
+//     back to zero; every History entry lists who it knocked back
+//   - The first player to reach the target wins the leg
+func startGotcha(g *play) {
+	state, scores := g.state, g.scores
+	goal := defaultGotchaTarget
+	if state.Config.StartingScore != nil {
+		goal = *state.Config.StartingScore
+	}
+
+	match := newMatchScore(state, 0)
+	g.match = &match
+
+	// Per-player score for the CURRENT leg
+	points := make([]int, len(state.Players))
+	resetLeg := func() {
+		for i := range points {
+			points[i] = 0
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+	}
+	resetLeg()
+
+	g.apply = func(t *Throw, idx int) {
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		cand := points[idx] + t.VisitScore
+		if cand > goal {
+			// bust: ignore this visit
+			zero := 0
+			scores[idx].LastVisit = &zero
+			return
+		}
+		points[idx] = cand
+		visit := t.VisitScore
+		scores[idx].LastVisit = &visit
+
+		if t.VisitScore > 0 && cand < goal {
+			for i, p := range state.Players {
+				if i != idx && points[i] == cand {
+					points[i] = 0
+					t.KnockedBack = append(t.KnockedBack, p.ID)
+				}
+			}
+		}
+
+		for i, p := range state.Players {
+			leg.ScoresByPlayer[p.ID] = points[i]
+		}
+
+		if cand != goal {
+			return
+		}
+
+		if !finishLeg(&match, t.PlayerID, *t) {
+			startNextLegOrSet(&match, 0, state.Players)
+			resetLeg()
+		}
+	}
+
+	g.report = func() {
+		for i := range scores {
+			p := points[i]
+			r := goal - points[i]
+			scores[i].Points = &p
+			scores[i].Remaining = &r
+		}
+	}
+}
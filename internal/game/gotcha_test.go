@@ -0,0 +1,74 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGotchaValidateConfig(t *testing.T) {
+	mode, _ := LookupMode("Gotcha")
+	tests := []struct {
+		name    string
+		target  *int
+		wantErr bool
+	}{
+		{name: "default"},
+		{name: "target", target: intPtr(101)},
+		{name: "lowest target", target: intPtr(1)},
+		{name: "zero", target: intPtr(0), wantErr: true},
+		{name: "negative", target: intPtr(-50), wantErr: true},
+	}
+	for _, tt := range tests {
+		req := CreateGameRequest{Config: GameConfig{StartingScore: tt.target}}
+		if err := mode.ValidateConfig(req); (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestGotchaKnockBack(t *testing.T) {
+	config := GameConfig{Mode: "Gotcha", StartingScore: intPtr(100)}
+	tests := []struct {
+		name       string
+		players    []string
+		visits     []testVisit
+		wantPoints []int
+		wantKnock  []string // knocked back by the last visit
+	}{
+		{name: "landing on a score", players: []string{"a", "b"}, visits: []testVisit{vt("a", 60), vt("b", 60)}, wantPoints: []int{0, 60}, wantKnock: []string{"a"}},
+		{name: "only the player on that score", players: []string{"a", "b", "c"}, visits: []testVisit{vt("a", 40), vt("b", 20), vt("c", 40)}, wantPoints: []int{0, 20, 40}, wantKnock: []string{"a"}},
+		{name: "a different score", players: []string{"a", "b"}, visits: []testVisit{vt("a", 60), vt("b", 45)}, wantPoints: []int{60, 45}},
+		{name: "not with an empty visit", players: []string{"a", "b"}, visits: []testVisit{vt("a", 0), vt("b", 0)}, wantPoints: []int{0, 0}},
+		{name: "a bust changes nothing", players: []string{"a", "b"}, visits: []testVisit{vt("a", 50), vt("b", 90), vt("a", 60)}, wantPoints: []int{50, 90}},
+	}
+	for _, tt := range tests {
+		state := testGame(t, config, tt.players, tt.visits)
+		for i, want := range tt.wantPoints {
+			if got := state.MatchScore.Sets[0].Legs[0].ScoresByPlayer[tt.players[i]]; got != want {
+				t.Errorf("%s: %s has %d, want %d", tt.name, tt.players[i], got, want)
+			}
+		}
+		if got := state.History[len(state.History)-1].KnockedBack; !slices.Equal(got, tt.wantKnock) {
+			t.Errorf("%s: knocked back %v, want %v", tt.name, got, tt.wantKnock)
+		}
+	}
+}
+
+func TestGotchaLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "target hit exactly",
+			config:  GameConfig{Mode: "Gotcha", StartingScore: intPtr(100)},
+			players: []string{"a", "b"},
+			visits:  []testVisit{vt("a", 60), vt("b", 60), vt("a", 30), vt("b", 40)},
+			want:    "b",
+		},
+		{
+			name:    "over the target busts",
+			config:  GameConfig{Mode: "Gotcha", StartingScore: intPtr(100)},
+			players: []string{"a", "b"},
+			visits:  []testVisit{vt("a", 90), vt("b", 20), vt("a", 20), vt("b", 20), vt("a", 10)},
+			want:    "a",
+		},
+	})
+}
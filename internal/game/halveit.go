@@ -0,0 +1,112 @@
+package game
+
+// defaultHalveItTargets is the sheet used when the config has no targets.
+var defaultHalveItTargets = []string{"20", "16", "D7", "14", "17", "T10", "25", "Bull"}
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "HalveIt",
+			Description: "One target per round; a visit that misses it halves your score.",
+			Darts:       true,
+			Teams:       true,
+			Options: []ModeOption{
+				{Key: "targets", Type: "string[]", Default: defaultHalveItTargets, Description: "the sheet, one target per round"},
+			},
+		},
+		validate: validateTargets,
+		start:    startHalveIt,
+	})
+}
+
+// startHalveIt scores a Halve-It match.
+//
+// Rules implemented:
+//   - Round N targets the Nth entry of the configured sheet (targets)
+//   - Every dart on the target scores its value
+//   - A round without a single hit halves the player's total (rounded down)
+//   - The highest total after the last round wins the leg; a tie is played
+//     off with extra rounds on the last target
+func startHalveIt(g *play) {
+	state, scores := g.state, g.scores
+	specs := state.Config.Targets
+	if len(specs) == 0 {
+		specs = defaultHalveItTargets
+	}
+	targets, err := parseTargets(specs)
+	if err != nil {
+		// Validated at creation; fall back rather than fail a read.
+		targets, _ = parseTargets(defaultHalveItTargets)
+	}
+
+	roundTarget := func(round int) target {
+		return targets[min(round, len(targets))-1]
+	}
+
+	match := newMatchScore(state, 0)
+	g.match = &match
+
+	// Per-player totals and rounds played, plus the sheet, for the CURRENT leg
+	points := make([]int, len(state.Players))
+	rounds := newLegRounds(len(state.Players), len(targets))
+	var sheet []RoundScore
+	resetLeg := func() {
+		for i := range points {
+			points[i] = 0
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+		sheet = make([]RoundScore, 0, len(targets))
+		rounds.reset()
+	}
+	resetLeg()
+
+	g.apply = func(t *Throw, idx int) {
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		round := rounds.play(idx)
+		tgt := roundTarget(round)
+
+		visitPoints := 0
+		hit := false
+		for _, d := range t.Darts {
+			if tgt.Matches(d) {
+				hit = true
+				visitPoints += d.Score()
+			}
+		}
+		if hit {
+			points[idx] += visitPoints
+		} else {
+			points[idx] /= 2
+		}
+
+		if len(sheet) < round {
+			sheet = append(sheet, RoundScore{
+				Round:          round,
+				Target:         tgt.Label,
+				ScoresByPlayer: make(map[string]int, len(state.Players)),
+				TotalsByPlayer: make(map[string]int, len(state.Players)),
+			})
+		}
+		sheet[round-1].ScoresByPlayer[t.PlayerID] = visitPoints
+		sheet[round-1].TotalsByPlayer[t.PlayerID] = points[idx]
+
+		leg.ScoresByPlayer[t.PlayerID] = points[idx]
+		scores[idx].LastVisit = &visitPoints
+
+		if rounds.settle(&match, *t, 0, state.Players, points, soleHighest) {
+			resetLeg()
+		}
+	}
+
+	g.report = func() {
+		for i := range scores {
+			p := points[i]
+			scores[i].Points = &p
+		}
+		round := rounds.current()
+		state.Round = &round
+		state.Rounds = sheet
+	}
+}
@@ -0,0 +1,41 @@
+package game
+
+import "testing"
+
+func TestHalveItScores(t *testing.T) {
+	config := GameConfig{Mode: "HalveIt", ModeOptions: ModeOptions{Targets: []string{"20", "D7", "Bull"}}}
+	tests := []struct {
+		name   string
+		visits []testVisit
+		want   int
+	}{
+		{name: "hits score their value", visits: []testVisit{v("a", "S20", "T20", "S1")}, want: 80},
+		{name: "a round without a hit halves", visits: []testVisit{v("a", "T20", "S20", "S1"), v("b", "M"), v("a", "S7", "T7")}, want: 40},
+		{name: "halving rounds down", visits: []testVisit{v("a", "T20", "S1", "S1"), v("b", "M"), v("a", "D7"), v("b", "M"), v("a", "M")}, want: 37},
+	}
+	for _, tt := range tests {
+		state := testGame(t, config, []string{"a", "b"}, tt.visits)
+		if got := *scoreOf(state, "a").Points; got != tt.want {
+			t.Errorf("%s: %d points, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHalveItLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "highest after the sheet",
+			config:  GameConfig{Mode: "HalveIt", ModeOptions: ModeOptions{Targets: []string{"20", "19"}}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "S20"), v("b", "M"), v("a", "M"), v("b", "S19")},
+			want:    "b",
+		},
+		{
+			name:    "tie played off on the last target",
+			config:  GameConfig{Mode: "HalveIt", ModeOptions: ModeOptions{Targets: []string{"20"}}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "S20"), v("b", "S20"), v("a", "M"), v("b", "S20")},
+			want:    "b",
+		},
+	})
+}
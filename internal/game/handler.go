@@ -103,6 +103,37 @@ func (h *Handler) UndoLastThrow(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, state)
 }
 
+// POST /api/games/{id}/bull-off
+func (h *Handler) PostBullOff(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "missing game id", http.StatusBadRequest)
+		return
+	}
+
+	var req BullOffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	state, err := h.repo.DecideBullOff(ctx, id, req)
+	if err != nil {
+		http.Error(w, "failed to record bull-off: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, state)
+}
+
+// GET /api/modes
+func (h *Handler) ListModes(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, Modes())
+}
+
 // Helper to write JSON responses.
 func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,82 @@
+package game
+
+import "errors"
+
+// defaultHighScoreRounds is the number of visits per player when the config
+// does not set rounds.
+const defaultHighScoreRounds = 7
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "HighScore",
+			Description: "Count up: the highest total after the rounds wins.",
+			Teams:       true,
+			Options: []ModeOption{
+				{Key: "rounds", Type: "int", Default: defaultHighScoreRounds, Description: "visits per player"},
+			},
+		},
+		validate: func(req CreateGameRequest) error {
+			if req.Config.Rounds < 0 {
+				return errors.New("rounds must be > 0, or 0 for the default")
+			}
+			return nil
+		},
+		start: startHighScore,
+	})
+}
+
+// startHighScore scores a HighScore (Count-Up) match.
+//
+// Rules implemented:
+//   - Every player throws the configured number of visits (default 7);
+//     visit totals simply add up
+//   - The highest total once everyone has thrown all rounds wins the leg
+//   - A tie for the lead is played off with sudden-death visits: one more
+//     round for everyone until a single player leads
+func startHighScore(g *play) {
+	state, scores := g.state, g.scores
+	numRounds := state.Config.Rounds
+	if numRounds <= 0 {
+		numRounds = defaultHighScoreRounds
+	}
+
+	match := newMatchScore(state, 0)
+	g.match = &match
+
+	// Per-player totals and rounds played for the CURRENT leg
+	points := make([]int, len(state.Players))
+	rounds := newLegRounds(len(state.Players), numRounds)
+	resetLeg := func() {
+		for i := range points {
+			points[i] = 0
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+		rounds.reset()
+	}
+	resetLeg()
+
+	g.apply = func(t *Throw, idx int) {
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		rounds.play(idx)
+		points[idx] += t.VisitScore
+		leg.ScoresByPlayer[t.PlayerID] = points[idx]
+		visit := t.VisitScore
+		scores[idx].LastVisit = &visit
+
+		if rounds.settle(&match, *t, 0, state.Players, points, soleHighest) {
+			resetLeg()
+		}
+	}
+
+	g.report = func() {
+		for i := range scores {
+			p := points[i]
+			scores[i].Points = &p
+		}
+		round := rounds.current()
+		state.Round = &round
+	}
+}
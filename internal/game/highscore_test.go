@@ -0,0 +1,46 @@
+package game
+
+import "testing"
+
+func TestHighScoreValidateConfig(t *testing.T) {
+	mode, _ := LookupMode("HighScore")
+	for _, tt := range []struct {
+		rounds  int
+		wantErr bool
+	}{
+		{rounds: 0},
+		{rounds: 10},
+		{rounds: -1, wantErr: true},
+	} {
+		req := CreateGameRequest{Config: GameConfig{ModeOptions: ModeOptions{Rounds: tt.rounds}}}
+		if err := mode.ValidateConfig(req); (err != nil) != tt.wantErr {
+			t.Errorf("rounds %d: error = %v, wantErr %t", tt.rounds, err, tt.wantErr)
+		}
+	}
+}
+
+func TestHighScoreLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "highest after the rounds",
+			config:  GameConfig{Mode: "HighScore", ModeOptions: ModeOptions{Rounds: 2}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{vt("a", 60), vt("b", 45), vt("a", 20), vt("b", 46)},
+			want:    "b",
+		},
+		{
+			name:    "tie played off",
+			config:  GameConfig{Mode: "HighScore", ModeOptions: ModeOptions{Rounds: 1}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{vt("a", 60), vt("b", 60), vt("a", 41), vt("b", 40)},
+			want:    "a",
+		},
+		{
+			name:    "default rounds",
+			config:  GameConfig{Mode: "HighScore"},
+			players: []string{"a", "b"},
+			visits:  append(repeat(6, vt("a", 26), vt("b", 26)), vt("a", 26), vt("b", 100)),
+			want:    "b",
+		},
+	})
+}
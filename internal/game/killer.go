@@ -0,0 +1,211 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// defaultKillerLives is the number of lives each player starts a leg with.
+const defaultKillerLives = 3
+
+// assignKillerNumbers gives every player a distinct number from 1 to 20.
+// Numbers in chosen (playerId -> number) are kept; everyone else draws a
+// random free number.
+func assignKillerNumbers(playerIDs []string, chosen map[string]int) (map[string]int, error) {
+	if len(playerIDs) > 20 {
+		return nil, fmt.Errorf("killer supports at most 20 players")
+	}
+
+	numbers := make(map[string]int, len(playerIDs))
+	taken := make(map[int]bool, len(playerIDs))
+	inGame := make(map[string]bool, len(playerIDs))
+	for _, pid := range playerIDs {
+		inGame[pid] = true
+	}
+
+	for pid, n := range chosen {
+		if !inGame[pid] {
+			return nil, fmt.Errorf("killer number given for player %s who is not in the game", pid)
+		}
+		if n < 1 || n > 20 {
+			return nil, fmt.Errorf("killer number for player %s must be between 1 and 20", pid)
+		}
+		if taken[n] {
+			return nil, fmt.Errorf("killer number %d is chosen twice", n)
+		}
+		taken[n] = true
+		numbers[pid] = n
+	}
+
+	free := make([]int, 0, 20)
+	for _, n := range rand.Perm(20) {
+		if !taken[n+1] {
+			free = append(free, n+1)
+		}
+	}
+	for _, pid := range playerIDs {
+		if _, ok := numbers[pid]; ok {
+			continue
+		}
+		numbers[pid] = free[0]
+		free = free[1:]
+	}
+
+	return numbers, nil
+}
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "Killer",
+			Description: "Hit your own double to become a killer, then take lives off the others' numbers.",
+			Darts:       true,
+			Options: []ModeOption{
+				{Key: "lives", Type: "int", Default: defaultKillerLives, Description: "lives per player (numbers are assigned with killerNumbers)"},
+			},
+		},
+		validate: func(req CreateGameRequest) error {
+			// With one player, the last player standing is there from
+			// the start.
+			if len(req.PlayerIDs) < 2 {
+				return errors.New("killer needs at least two players")
+			}
+			if req.Config.Lives < 0 {
+				return errors.New("lives must be > 0, or 0 for the default")
+			}
+			return nil
+		},
+		setup: setupKiller,
+		start: startKiller,
+	})
+}
+
+// setupKiller gives every player of a new Killer game their number.
+func setupKiller(req CreateGameRequest, players []GamePlayer) error {
+	numbers, err := assignKillerNumbers(req.PlayerIDs, req.KillerNumbers)
+	if err != nil {
+		return err
+	}
+	for i := range players {
+		n := numbers[players[i].ID]
+		players[i].Number = &n
+	}
+	return nil
+}
+
+// startKiller scores a Killer match.
+//
+// Rules implemented:
+//   - Every player owns a number (assigned at game creation); only doubles
+//     count
+//   - Hitting the double of your own number makes you a killer
+//   - A killer takes a life from each opponent whose double they hit, and
+//     loses one of their own when hitting their own double again
+//   - Players with no lives left are out and skipped in the rotation; the
+//     last player standing wins the leg
+func startKiller(g *play) {
+	state, scores := g.state, g.scores
+	startLives := state.Config.Lives
+	if startLives <= 0 {
+		startLives = defaultKillerLives
+	}
+
+	// number -> owning player index
+	owner := make(map[int]int, len(state.Players))
+	for i, p := range state.Players {
+		if p.Number != nil {
+			owner[*p.Number] = i
+		}
+	}
+
+	match := newMatchScore(state, startLives)
+	g.match = &match
+
+	// Per-player lives and killer status for the CURRENT leg
+	lives := make([]int, len(state.Players))
+	killer := make([]bool, len(state.Players))
+	resetLeg := func() {
+		for i := range lives {
+			lives[i] = startLives
+			killer[i] = false
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+	}
+	resetLeg()
+
+	survivor := func() int {
+		alive := -1
+		for i, l := range lives {
+			if l <= 0 {
+				continue
+			}
+			if alive != -1 {
+				return -1
+			}
+			alive = i
+		}
+		return alive
+	}
+
+	nextIdx := 0
+
+	g.apply = func(t *Throw, idx int) {
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		livesTaken := 0
+		for _, d := range t.Darts {
+			if d.IsMiss() || d.Multiplier != 2 {
+				continue
+			}
+			victim, ok := owner[d.Segment]
+			if !ok || lives[victim] <= 0 {
+				continue
+			}
+
+			if !killer[idx] {
+				if victim == idx {
+					killer[idx] = true
+				}
+				continue
+			}
+
+			lives[victim]--
+			if victim != idx {
+				livesTaken++
+			}
+			if lives[idx] <= 0 {
+				break
+			}
+		}
+
+		for i, p := range state.Players {
+			leg.ScoresByPlayer[p.ID] = lives[i]
+		}
+		scores[idx].LastVisit = &livesTaken
+
+		nextIdx = nextActiveIndex(len(lives), idx, func(i int) bool { return lives[i] > 0 })
+
+		winnerIdx := survivor()
+		if winnerIdx == -1 {
+			return
+		}
+
+		if !finishLeg(&match, state.Players[winnerIdx].ID, *t) {
+			startNextLegOrSet(&match, startLives, state.Players)
+			resetLeg()
+			nextIdx = (idx + 1) % len(state.Players)
+		}
+	}
+	g.next = func() int { return nextIdx }
+
+	g.report = func() {
+		for i := range scores {
+			l := lives[i]
+			k := killer[i]
+			scores[i].Lives = &l
+			scores[i].Killer = &k
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package game
+
+import "testing"
+
+func TestAssignKillerNumbers(t *testing.T) {
+	players := []string{"a", "b", "c"}
+	tests := []struct {
+		name    string
+		chosen  map[string]int
+		wantErr bool
+	}{
+		{name: "all drawn"},
+		{name: "some chosen", chosen: map[string]int{"a": 20, "c": 1}},
+		{name: "out of range", chosen: map[string]int{"a": 21}, wantErr: true},
+		{name: "chosen twice", chosen: map[string]int{"a": 5, "b": 5}, wantErr: true},
+		{name: "not in the game", chosen: map[string]int{"x": 5}, wantErr: true},
+	}
+	for _, tt := range tests {
+		numbers, err := assignKillerNumbers(players, tt.chosen)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %t", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		seen := make(map[int]bool)
+		for _, pid := range players {
+			n, ok := numbers[pid]
+			if !ok || n < 1 || n > 20 || seen[n] {
+				t.Errorf("%s: %s got number %d (assigned %t), want a distinct 1-20", tt.name, pid, n, ok)
+			}
+			seen[n] = true
+		}
+		for pid, n := range tt.chosen {
+			if numbers[pid] != n {
+				t.Errorf("%s: %s got number %d, want the chosen %d", tt.name, pid, numbers[pid], n)
+			}
+		}
+	}
+}
+
+func TestKillerValidateConfig(t *testing.T) {
+	mode, _ := LookupMode("Killer")
+	tests := []struct {
+		name    string
+		req     CreateGameRequest
+		wantErr bool
+	}{
+		{name: "default lives", req: CreateGameRequest{PlayerIDs: []string{"a", "b"}}},
+		{name: "lives", req: CreateGameRequest{PlayerIDs: []string{"a", "b"}, Config: GameConfig{ModeOptions: ModeOptions{Lives: 5}}}},
+		{name: "negative lives", req: CreateGameRequest{PlayerIDs: []string{"a", "b"}, Config: GameConfig{ModeOptions: ModeOptions{Lives: -1}}}, wantErr: true},
+		{name: "one player", req: CreateGameRequest{PlayerIDs: []string{"a"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := mode.ValidateConfig(tt.req); (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestKillerLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "last standing",
+			config:  GameConfig{Mode: "Killer", ModeOptions: ModeOptions{Lives: 2}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "D1", "D2"), v("b", "D2", "M", "M"), v("a", "D2")},
+			want:    "a",
+		},
+		{
+			name:    "a killer out on their own double",
+			config:  GameConfig{Mode: "Killer", ModeOptions: ModeOptions{Lives: 1}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "D1", "M", "M"), v("b", "M"), v("a", "D1")},
+			want:    "b",
+		},
+	})
+}
+
+func TestKillerTurnOrder(t *testing.T) {
+	config := GameConfig{Mode: "Killer", Legs: 2, ModeOptions: ModeOptions{Lives: 1}}
+	players := []string{"a", "b", "c"}
+	tests := []struct {
+		name   string
+		visits []testVisit
+		want   string
+	}{
+		{name: "in seat order", visits: []testVisit{v("a", "M")}, want: "b"},
+		{name: "skips a player knocked out", visits: []testVisit{v("a", "D1", "D2")}, want: "c"},
+		{name: "keeps skipping them", visits: []testVisit{v("a", "D1", "D2"), v("c", "M")}, want: "a"},
+		{name: "skips the thrower's own seat once out", visits: []testVisit{v("a", "M"), v("b", "D2", "D2"), v("c", "M")}, want: "a"},
+		{name: "next leg in full", visits: []testVisit{v("a", "D1", "D2", "D3")}, want: "b"},
+	}
+	for _, tt := range tests {
+		state := testGame(t, config, players, tt.visits)
+		if state.CurrentPlayerID != tt.want {
+			t.Errorf("%s: current player %q, want %q", tt.name, state.CurrentPlayerID, tt.want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// GameMode is one game type. Scores are never stored: every read starts a
+// Play of the game and feeds it the throw history, one visit at a time.
+type GameMode interface {
+	// Schema describes the mode and its config options (GET /api/modes).
+	Schema() ModeSchema
+	// ValidateConfig checks the mode's part of a new game: its config
+	// options, the number of players, handicaps.
+	ValidateConfig(req CreateGameRequest) error
+	// Setup fills in the mode's per-player fields of a new game's players
+	// (Killer numbers) once the request is valid.
+	Setup(req CreateGameRequest, players []GamePlayer) error
+	// Start begins scoring state's game.
+	Start(state *GameState) Play
+}
+
+// Play is one game being scored by its mode. The mode keeps the legs,
+// scores and turn order itself; the GameState is only written by Report.
+type Play interface {
+	// ApplyThrow applies the next visit of the history. The mode may
+	// annotate it (Gotcha knock-backs).
+	ApplyThrow(t *Throw)
+	// CurrentPlayer returns the seat (player ID) that throws next.
+	CurrentPlayer() string
+	// Winner returns the match winner, if the match has been won.
+	Winner() *string
+	// Report writes the scores, the MatchScore and any round state to the
+	// GameState the play was started on.
+	Report()
+}
+
+// ModeSchema describes a mode for clients building a game setup screen.
+type ModeSchema struct {
+	Name        string       `json:"name"` // GameConfig.Mode
+	Description string       `json:"description"`
+	Darts       bool         `json:"darts"`     // throws need per-dart input
+	Teams       bool         `json:"teams"`     // can be played in teams
+	Handicaps   bool         `json:"handicaps"` // accepts per-player startingScores
+	Checkouts   bool         `json:"checkouts"` // visit totals may name their checkoutDouble
+	Options     []ModeOption `json:"options"`
+}
+
+// ModeOption is one mode-specific GameConfig field. Legs, sets, format,
+// starter, bullOff and tiebreak apply to every mode and are not listed.
+type ModeOption struct {
+	Key         string `json:"key"`  // JSON field of GameConfig
+	Type        string `json:"type"` // "int", "bool", "string" or "string[]"
+	Default     any    `json:"default,omitempty"`
+	Values      []any  `json:"values,omitempty"` // allowed values, when limited
+	Description string `json:"description"`
+}
+
+var modes = make(map[string]GameMode)
+
+// RegisterMode makes a mode available under its schema name. It panics if
+// the name is already taken.
+func RegisterMode(m GameMode) {
+	name := m.Schema().Name
+	if _, dup := modes[name]; dup {
+		panic(fmt.Sprintf("game: mode %q registered twice", name))
+	}
+	modes[name] = m
+}
+
+// LookupMode returns the mode registered under name.
+func LookupMode(name string) (GameMode, bool) {
+	m, ok := modes[name]
+	return m, ok
+}
+
+// Modes returns the schemas of all registered modes, by name.
+func Modes() []ModeSchema {
+	schemas := make([]ModeSchema, 0, len(modes))
+	for _, m := range modes {
+		schemas = append(schemas, m.Schema())
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Name < schemas[j].Name })
+	return schemas
+}
+
+// funcMode is a GameMode built from functions. start sets up a play: the
+// mode's per-leg state lives in closures, which it hands over as g.apply,
+// g.report and, if the turn order is not simply seat after seat, g.next.
+type funcMode struct {
+	schema   ModeSchema
+	validate func(req CreateGameRequest) error
+	setup    func(req CreateGameRequest, players []GamePlayer) error
+	start    func(p *play)
+}
+
+func (m funcMode) Schema() ModeSchema { return m.schema }
+
+func (m funcMode) ValidateConfig(req CreateGameRequest) error {
+	if m.validate == nil {
+		return nil
+	}
+	return m.validate(req)
+}
+
+func (m funcMode) Setup(req CreateGameRequest, players []GamePlayer) error {
+	if m.setup == nil {
+		return nil
+	}
+	return m.setup(req, players)
+}
+
+func (m funcMode) Start(state *GameState) Play {
+	p := &play{
+		state:       state,
+		playerIndex: make(map[string]int, len(state.Players)),
+		scores:      make([]PlayerScore, len(state.Players)),
+		last:        -1,
+	}
+	for i, pl := range state.Players {
+		p.playerIndex[pl.ID] = i
+		p.scores[i] = PlayerScore{PlayerID: pl.ID}
+	}
+	m.start(p)
+	return p
+}
+
+// play is the Play of a funcMode.
+type play struct {
+	state       *GameState
+	playerIndex map[string]int
+	scores      []PlayerScore
+	match       *MatchScore
+	last        int // seat index of the last player to throw, -1 before any throw
+
+	apply  func(t *Throw, idx int) // applies a visit by the player at seat idx
+	next   func() int              // seat index to throw next; default the seat after last
+	report func()                  // writes the mode's own fields to the GameState
+}
+
+func (p *play) ApplyThrow(t *Throw) {
+	if p.match.FinishedAt != nil {
+		// ignore any throws after the match finished (shouldn't exist)
+		return
+	}
+	idx, ok := p.playerIndex[t.PlayerID]
+	if !ok {
+		return
+	}
+	p.last = idx
+	// Whatever the mode, per-dart input shows as the last three darts.
+	p.scores[idx].LastThree = dartScores(t.Darts)
+	p.apply(t, idx)
+}
+
+func (p *play) CurrentPlayer() string {
+	if p.next != nil {
+		return p.state.Players[p.next()].ID
+	}
+	return p.state.Players[(p.last+1)%len(p.state.Players)].ID
+}
+
+func (p *play) Winner() *string { return p.match.WinnerID }
+
+func (p *play) Report() {
+	if p.report != nil {
+		p.report()
+	}
+	p.state.Scores = p.scores
+	p.state.MatchScore = p.match
+}
+
+// legRounds keeps count of the visits (or attempts) each player has played in
+// the current leg of a mode played over a set number of rounds, where the
+// leaders play off a round at a time when the last round ends in a tie.
+type legRounds struct {
+	regular int   // rounds in the leg before any play-off
+	toPlay  int   // rounds in the leg, play-offs included
+	played  []int // rounds played, by seat index
+}
+
+func newLegRounds(players, regular int) *legRounds {
+	return &legRounds{regular: regular, toPlay: regular, played: make([]int, players)}
+}
+
+// reset starts a new leg.
+func (r *legRounds) reset() {
+	clear(r.played)
+	r.toPlay = r.regular
+}
+
+// play counts a round for the player at seat idx and returns its (1-based)
+// number.
+func (r *legRounds) play(idx int) int {
+	r.played[idx]++
+	return r.played[idx]
+}
+
+// next returns the round the player at seat idx plays next.
+func (r *legRounds) next(idx int) int { return r.played[idx] + 1 }
+
+// done reports whether the player at seat idx has played every round.
+func (r *legRounds) done(idx int) bool { return r.played[idx] >= r.toPlay }
+
+// current returns the round in play: the one after the fewest played.
+func (r *legRounds) current() int { return slices.Min(r.played) + 1 }
+
+// settle ends the leg once every player has played every round: the player
+// pick (soleHighest or soleLowest) chooses from totals wins it after t, and a
+// tie adds a play-off round instead. It reports whether a new leg or set was
+// started, for the caller to reset its own per-leg state.
+func (r *legRounds) settle(match *MatchScore, t Throw, start int, players []GamePlayer, totals []int, pick func([]int) int) bool {
+	for i := range r.played {
+		if !r.done(i) {
+			return false
+		}
+	}
+	winnerIdx := pick(totals)
+	if winnerIdx == -1 {
+		r.toPlay++
+		return false
+	}
+	if finishLeg(match, players[winnerIdx].ID, t) {
+		return false
+	}
+	startNextLegOrSet(match, start, players)
+	return true
+}
@@ -0,0 +1,38 @@
+package game
+
+import "testing"
+
+func TestLegRoundsSettle(t *testing.T) {
+	players := []GamePlayer{{ID: "a", Seat: 1}, {ID: "b", Seat: 2}}
+	match := newMatchScore(&GameState{Players: players, Config: GameConfig{Legs: 2}}, 0)
+	rounds := newLegRounds(len(players), 2)
+
+	// Level after the regular rounds: a play-off round is added.
+	for range 2 {
+		rounds.play(0)
+		rounds.play(1)
+	}
+	if rounds.settle(&match, Throw{}, 0, players, []int{10, 10}, soleHighest) || rounds.done(0) {
+		t.Fatalf("tied leg settled, or no play-off round added")
+	}
+	if got := rounds.current(); got != 3 {
+		t.Errorf("play-off is round %d, want 3", got)
+	}
+
+	rounds.play(0)
+	if rounds.settle(&match, Throw{}, 0, players, []int{15, 10}, soleHighest) {
+		t.Fatalf("leg settled before everyone played the play-off")
+	}
+	rounds.play(1)
+	if !rounds.settle(&match, Throw{}, 0, players, []int{15, 12}, soleHighest) {
+		t.Fatalf("leg not settled after the play-off")
+	}
+	if got := firstLegWinner(&GameState{MatchScore: &match}); got != "a" {
+		t.Errorf("leg won by %q, want a", got)
+	}
+
+	rounds.reset()
+	if got := rounds.current(); got != 1 || rounds.toPlay != 2 {
+		t.Errorf("after reset: round %d of %d, want 1 of 2", got, rounds.toPlay)
+	}
+}
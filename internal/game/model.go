@@ -4,28 +4,115 @@ import "time"
 
 // GameConfig mirrors the frontend config object structure.
 type GameConfig struct {
-	Mode          string `json:"mode"`                    // "X01", "Cricket", etc.
-	StartingScore *int   `json:"startingScore,omitempty"` // only for X01
-	Legs          int    `json:"legs"`
-	Sets          int    `json:"sets"`
-	DoubleOut     bool   `json:"doubleOut"`
+	Mode          string    `json:"mode"`                    // "X01", "Cricket", "CutThroat", etc.
+	StartingScore *int      `json:"startingScore,omitempty"` // X01 and Checkout121 start, Gotcha target
+	Legs          int       `json:"legs"`                    // legs per set, read according to format
+	Sets          int       `json:"sets"`                    // sets per match, read according to format
+	Format        Format    `json:"format,omitempty"`        // how legs and sets are counted (default "firstTo")
+	Starter       Starter   `json:"starter,omitempty"`       // who starts each leg and set (default "alternate")
+	BullOff       bool      `json:"bullOff,omitempty"`       // a bull-off decides who starts leg 1 (default: seat 1)
+	Tiebreak      *Tiebreak `json:"tiebreak,omitempty"`      // deciding-set tiebreak (first-to and best-of only)
+	InRule        CheckRule `json:"inRule,omitempty"`        // X01: how a player opens (default "straight")
+	OutRule       CheckRule `json:"outRule,omitempty"`       // X01: how a leg is finished (default "double")
+
+	// Legacy X01 flags, still accepted when creating a game and filled in
+	// on reads: true stands for the "double" rule, false for "straight"
+	// (and reads false for "master"; see legacyCheckRule).
+	DoubleIn  *bool `json:"doubleIn,omitempty"`
+	DoubleOut *bool `json:"doubleOut,omitempty"`
+
+	ModeOptions
+}
+
+// CheckRule is the X01 rule for the dart that opens (in) or finishes (out)
+// a player's leg.
+type CheckRule string
+
+const (
+	RuleStraight CheckRule = "straight" // any dart
+	RuleDouble   CheckRule = "double"   // a double (the inner bull counts)
+	RuleMaster   CheckRule = "master"   // a double or a treble
+)
+
+// Format is how the configured number of legs and sets decides a set and the
+// match.
+type Format string
+
+const (
+	FormatFirstTo Format = "firstTo" // the first to win legs/sets takes it
+	FormatBestOf  Format = "bestOf"  // a majority of legs/sets takes it
+	FormatFixed   Format = "fixed"   // all legs/sets are played; the most won takes it, a tie is a draw
+)
+
+// Tiebreak makes the deciding set (the only set, or the one both leaders
+// need to win the match) a two-clear-legs affair: reaching the legs to win
+// is not enough until the lead is winBy legs, unless the leader reaches cap,
+// so at cap-1 apiece the next leg is sudden death.
+type Tiebreak struct {
+	WinBy int `json:"winBy,omitempty"` // clear legs needed (default 2)
+	Cap   int `json:"cap,omitempty"`   // legs that win the set regardless of the lead (0 = no cap)
+}
+
+// Starter is the policy that picks who throws first in each new leg and set.
+type Starter string
+
+const (
+	StarterAlternate Starter = "alternate" // leg and set starters move on one seat each leg/set
+	StarterLoser     Starter = "loser"     // the loser of the last leg starts (the seat after the winner)
+	StarterWinner    Starter = "winner"    // the winner of the last leg starts
+)
+
+// ModeOptions holds the settings that only apply to some modes. They are
+// stored together as JSON in games.options.
+type ModeOptions struct {
+	Ring      string `json:"ring,omitempty"`      // AroundTheClock: "any" (default), "singles", "doubles", "trebles"
+	SkipAhead bool   `json:"skipAhead,omitempty"` // AroundTheClock: a double/treble advances 2/3 targets
+	Rounds    int    `json:"rounds,omitempty"`    // Shanghai, HighScore: number of rounds (default 7); Checkout121: attempts (default 10)
+	Lives     int    `json:"lives,omitempty"`     // Killer: lives per player (default 3)
+	Holes     int    `json:"holes,omitempty"`     // Golf: 9 (default) or 18
+	OnFail    string `json:"onFail,omitempty"`    // Checkout121: "stay" (default) or "reset" after a failed attempt
+	MaxVisits int    `json:"maxVisits,omitempty"` // X01: visits per player per leg (0 = no limit)
+	OnCap     string `json:"onCap,omitempty"`     // X01: "lowest" (default) remaining wins a capped leg, or "replay" it
+
+	// X01, Checkout121: the inner bull does not count as a double to finish.
+	NoBullFinish bool `json:"noBullFinish,omitempty"`
+
+	// HalveIt: the sheet, one target per round, e.g. ["20", "D7", "T10", "Bull"].
+	// Cricket, CutThroat, Tactics: the target set (defaults to the mode's own).
+	Targets []string `json:"targets,omitempty"`
 }
 
 // A player attached to a game, with fixed seating order.
 type GamePlayer struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-	Seat int    `json:"seat"`
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Seat   int     `json:"seat"`
+	Number *int    `json:"number,omitempty"` // Killer: the player's own number
+	TeamID *string `json:"teamId,omitempty"` // team games only
+
+	// X01 handicap: the player's own starting score, if it differs
+	StartingScore *int `json:"startingScore,omitempty"`
+}
+
+// GameTeam is one side of a team game; its players share one score and
+// take turns in PlayerIDs order.
+type GameTeam struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Seat      int      `json:"seat"`
+	PlayerIDs []string `json:"playerIds"`
 }
 
 // Game is returned to the frontend when creating or loading a game.
 type Game struct {
-	ID        string       `json:"id"`
-	Config    GameConfig   `json:"config"`
-	Status    string       `json:"status"`
-	Players   []GamePlayer `json:"players"`
-	CreatedAt time.Time    `json:"createdAt"`
-	WinnerID  *string      `json:"winnerId,omitempty"`
+	ID           string       `json:"id"`
+	Config       GameConfig   `json:"config"`
+	Status       string       `json:"status"`
+	Players      []GamePlayer `json:"players"`
+	Teams        []GameTeam   `json:"teams,omitempty"`
+	CreatedAt    time.Time    `json:"createdAt"`
+	WinnerID     *string      `json:"winnerId,omitempty"`
+	WinnerTeamID *string      `json:"winnerTeamId,omitempty"`
 }
 
 // CreateGameRequest matches EXACTLY what the frontend sends:
@@ -34,16 +121,74 @@ type Game struct {
 //	  "config": { ... },
 //	  "playerIds": ["uuid1", "uuid2"]
 //	}
+//
+// For Killer, "killerNumbers" optionally maps playerId -> number (1-20);
+// players without a chosen number get a random free one.
+//
+// For team play, "teams" replaces "playerIds":
+//
+//	"teams": [
+//	  { "name": "A", "playerIds": ["a1", "a2"] },
+//	  { "name": "B", "playerIds": ["b1", "b2"] }
+//	]
+//
+// and players are seated alternating between teams (a1, b1, a2, b2).
+//
+// For X01 handicaps, "startingScores" optionally maps playerId -> starting
+// score (e.g. 401 for the weaker player in a 501 game).
 type CreateGameRequest struct {
-	Config    GameConfig `json:"config"`
-	PlayerIDs []string   `json:"playerIds"`
+	Config         GameConfig          `json:"config"`
+	PlayerIDs      []string            `json:"playerIds"`
+	KillerNumbers  map[string]int      `json:"killerNumbers,omitempty"`
+	Teams          []CreateTeamRequest `json:"teams,omitempty"`
+	StartingScores map[string]int      `json:"startingScores,omitempty"`
+}
+
+type CreateTeamRequest struct {
+	Name      string   `json:"name"`
+	PlayerIDs []string `json:"playerIds"`
 }
 
+// PlayerScore is the score of one player, or of one team in team games
+// (then PlayerID holds the team ID and TeamID is set).
 type PlayerScore struct {
-	PlayerID  string `json:"playerId"`
-	Remaining *int   `json:"remaining,omitempty"`
-	LastVisit *int   `json:"lastVisit,omitempty"`
-	LastThree []int  `json:"lastThreeDarts,omitempty"`
+	PlayerID  string  `json:"playerId"`
+	TeamID    *string `json:"teamId,omitempty"`
+	Remaining *int    `json:"remaining,omitempty"`
+	LastVisit *int    `json:"lastVisit,omitempty"`
+	LastThree []int   `json:"lastThreeDarts,omitempty"` // points of each dart of the last visit, when entered per dart
+
+	// X01 with a double/master in-rule: whether the player has opened.
+	Opened *bool `json:"opened,omitempty"`
+
+	// Cricket, CutThroat, Tactics: marks per target label ("20", "D", "Bull", ...).
+	Marks map[string]int `json:"marks,omitempty"`
+
+	// Points-based modes: the leg total (points, runs or Golf strokes).
+	Points *int `json:"points,omitempty"`
+
+	// AroundTheClock, Shanghai, Bobs27, Baseball, Golf: the number to hit
+	// next (25 for bull). Checkout121: the current checkout target.
+	Target *int `json:"target,omitempty"`
+
+	// Checkout121: the highest target reached.
+	Best *int `json:"best,omitempty"`
+
+	// Checkout121: the darts left in the current attempt.
+	DartsLeft *int `json:"dartsLeft,omitempty"`
+
+	// Bobs27: whether the player dropped below zero and is out of the leg.
+	Out *bool `json:"out,omitempty"`
+
+	// Killer: lives left (0 = out) and whether the player is a killer.
+	Lives  *int  `json:"lives,omitempty"`
+	Killer *bool `json:"killer,omitempty"`
+}
+
+// Dart is a single dart of a visit.
+type Dart struct {
+	Segment    int `json:"segment"`    // 1-20, 25 for bull, 0 for a miss
+	Multiplier int `json:"multiplier"` // 1 single, 2 double, 3 treble
 }
 
 type Throw struct {
@@ -52,13 +197,51 @@ type Throw struct {
 	PlayerID    string    `json:"playerId"`
 	VisitScore  int       `json:"visitScore"`
 	DartsThrown int       `json:"dartsThrown"`
+	Darts       []Dart    `json:"darts,omitempty"`
 	CreatedAt   time.Time `json:"createdAt"`
+
+	// Visit-total input: the segment whose double finished the leg, if given
+	CheckoutDouble *int `json:"checkoutDouble,omitempty"`
+
+	// Gotcha: players knocked back to zero by this visit (computed, not stored)
+	KnockedBack []string `json:"knockedBack,omitempty"`
 }
 
+// CreateThrowRequest is one visit: either the visit total (visitScore and
+// dartsThrown, for quick entry) or the darts themselves, from which both are
+// derived. Darts are required for dart-scored modes (Cricket, ...) and may
+// be written as objects or in short notation, e.g. ["T20", "D16", "M"].
+// In X01 and Checkout121, a visit total that checks out may name its
+// finishing double in checkoutDouble (16 for D16, 25 for the bull) to have
+// the out-rule checked.
 type CreateThrowRequest struct {
-	PlayerID    string `json:"playerId"`
-	VisitScore  int    `json:"visitScore"`
-	DartsThrown int    `json:"dartsThrown"`
+	PlayerID       string `json:"playerId"`
+	VisitScore     int    `json:"visitScore"`
+	DartsThrown    int    `json:"dartsThrown"`
+	Darts          []Dart `json:"darts,omitempty"`
+	CheckoutDouble *int   `json:"checkoutDouble,omitempty"`
+}
+
+// BullOffEntry is one player's bull-off dart: its distance from the centre
+// of the bull (any unit, lower is closer) or its ranking (1 = closest).
+type BullOffEntry struct {
+	PlayerID string   `json:"playerId"`
+	Distance *float64 `json:"distance,omitempty"`
+	Rank     *int     `json:"rank,omitempty"`
+}
+
+// BullOffRequest submits the bull-off: one entry per player, or per team in
+// team games.
+type BullOffRequest struct {
+	Entries []BullOffEntry `json:"entries"`
+}
+
+// BullOff is the decided bull-off of a game (games.bull_off_result).
+type BullOff struct {
+	Entries   []BullOffEntry `json:"entries"`
+	WinnerID  string         `json:"winnerId"`  // player closest to the bull
+	StarterID string         `json:"starterId"` // starter of leg 1: the winner, or the winner's team
+	DecidedAt time.Time      `json:"decidedAt"`
 }
 
 // -----------------------
@@ -68,24 +251,69 @@ type CreateThrowRequest struct {
 type LegScore struct {
 	LegNumber      int            `json:"legNumber"`
 	StartingScore  int            `json:"startingScore"`
-	ScoresByPlayer map[string]int `json:"scoresByPlayer"` // playerId -> remaining
-	WinnerID       *string        `json:"winnerId,omitempty"`
-	FinishedAt     *time.Time     `json:"finishedAt,omitempty"`
+	StarterID      string         `json:"starterId"`      // playerId (teamId in team games) who throws first
+	ScoresByPlayer map[string]int `json:"scoresByPlayer"` // playerId (teamId in team games) -> remaining
+
+	// X01 handicaps: playerId -> starting score, set when players start
+	// from different scores
+	StartingScoresByPlayer map[string]int `json:"startingScoresByPlayer,omitempty"`
+	WinnerID               *string        `json:"winnerId,omitempty"`
+	Result                 LegResult      `json:"result,omitempty"` // X01: how the leg ended
+	FinishedAt             *time.Time     `json:"finishedAt,omitempty"`
+
+	finishedBy string // ID of the throw that finished the leg
 }
 
+// LegResult is how an X01 leg ended.
+type LegResult string
+
+const (
+	LegResultCheckout LegResult = "checkout" // a player checked out
+	LegResultCap      LegResult = "cap"      // visit limit reached: lowest remaining won
+	LegResultReplay   LegResult = "replay"   // visit limit reached without a winner: replayed
+)
+
 type SetScore struct {
 	SetNumber  int        `json:"setNumber"`
-	LegsToWin  int        `json:"legsToWin"`
+	LegsToWin  int        `json:"legsToWin"`            // 0 when only the number played counts (fixed)
+	LegsToPlay int        `json:"legsToPlay,omitempty"` // most legs in the set (best-of, fixed)
+	StarterID  string     `json:"starterId"`            // starter of the set's first leg
 	Legs       []LegScore `json:"legs"`
 	WinnerID   *string    `json:"winnerId,omitempty"`
+	Drawn      bool       `json:"drawn,omitempty"`
 	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	// Deciding: the set decides the match and is played under the match's
+	// tiebreak from its first leg, so it must be won by winBy clear legs.
+	Deciding bool `json:"deciding,omitempty"`
+
+	// Deciding-set tiebreak in play: the two leaders are both within a leg
+	// of the legs to win, so the set needs a clear lead; SuddenDeath once
+	// both are one leg short of the cap
+	Tiebreak    bool `json:"tiebreak,omitempty"`
+	SuddenDeath bool `json:"suddenDeath,omitempty"`
+}
+
+// RoundScore is one row of a round-based score sheet.
+type RoundScore struct {
+	Round          int            `json:"round"`
+	Target         string         `json:"target"`
+	ScoresByPlayer map[string]int `json:"scoresByPlayer"` // playerId -> scored this round
+	TotalsByPlayer map[string]int `json:"totalsByPlayer"` // playerId -> total after this round
 }
 
 type MatchScore struct {
-	SetsToWin       int        `json:"setsToWin"`
+	Format          Format     `json:"format"`
+	Starter         Starter    `json:"starter"`
+	Tiebreak        *Tiebreak  `json:"tiebreak,omitempty"`
+	SetsToWin       int        `json:"setsToWin"`            // 0 when only the number played counts (fixed)
+	SetsToPlay      int        `json:"setsToPlay,omitempty"` // most sets in the match (best-of, fixed)
 	CurrentSetIndex int        `json:"currentSetIndex"`
 	CurrentLegIndex int        `json:"currentLegIndex"`
 	Sets            []SetScore `json:"sets"`
+	WinnerID        *string    `json:"winnerId,omitempty"`
+	Drawn           bool       `json:"drawn,omitempty"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty"`
 }
 
 // -----------------------
@@ -97,14 +325,27 @@ type GameState struct {
 	Config          GameConfig    `json:"config"`
 	Status          string        `json:"status"`
 	Players         []GamePlayer  `json:"players"`
+	Teams           []GameTeam    `json:"teams,omitempty"`
 	Scores          []PlayerScore `json:"scores"`
 	CurrentPlayerID string        `json:"currentPlayerId"`
 	History         []Throw       `json:"history"`
 	CreatedAt       time.Time     `json:"createdAt"`
 
+	// Pre-match bull-off result, once decided (config.bullOff)
+	BullOff *BullOff `json:"bullOff,omitempty"`
+
 	// NEW: full legs/sets structure
 	MatchScore *MatchScore `json:"matchScore,omitempty"`
 
-	// Match winner (mirrors games.winner_id)
-	WinnerID *string `json:"winnerId,omitempty"`
+	// Round in progress in the current leg (round-based modes only)
+	Round *int `json:"round,omitempty"`
+
+	// Round-by-round score sheet of the current leg (HalveIt, Baseball
+	// innings, Golf holes)
+	Rounds []RoundScore `json:"rounds,omitempty"`
+
+	// Match winner (mirrors games.winner_id, or games.winner_team_id in
+	// team games)
+	WinnerID     *string `json:"winnerId,omitempty"`
+	WinnerTeamID *string `json:"winnerTeamId,omitempty"`
 }
@@ -26,6 +26,17 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 
 // CreateGame inserts a game row and its game_players, then returns the full GameState.
 func (r *Repository) CreateGame(ctx context.Context, req CreateGameRequest) (GameState, error) {
+	var teamOf []int
+	if len(req.Teams) > 0 {
+		if len(req.PlayerIDs) > 0 {
+			return GameState{}, errors.New("use either playerIds or teams, not both")
+		}
+		var err error
+		req.PlayerIDs, teamOf, err = teamSeating(req.Teams)
+		if err != nil {
+			return GameState{}, err
+		}
+	}
 	if len(req.PlayerIDs) == 0 {
 		return GameState{}, errors.New("at least one player is required")
 	}
@@ -34,12 +45,82 @@ func (r *Repository) CreateGame(ctx context.Context, req CreateGameRequest) (Gam
 	if mode == "" {
 		return GameState{}, errors.New("mode is required")
 	}
+	gameMode, ok := LookupMode(mode)
+	if !ok {
+		return GameState{}, fmt.Errorf("unknown mode %q", mode)
+	}
+	req.Config.Mode = mode
+	if len(req.Teams) > 0 && !gameMode.Schema().Teams {
+		return GameState{}, fmt.Errorf("%s is not supported for team games", mode)
+	}
 	if req.Config.Legs <= 0 {
 		return GameState{}, errors.New("legs must be > 0")
 	}
 	if req.Config.Sets <= 0 {
 		return GameState{}, errors.New("sets must be > 0")
 	}
+	if req.Config.Format == "" {
+		req.Config.Format = FormatFirstTo
+	}
+	if !req.Config.Format.valid() {
+		return GameState{}, errors.New("format must be one of firstTo, bestOf, fixed")
+	}
+	if tb := req.Config.Tiebreak; tb != nil {
+		if req.Config.Format == FormatFixed {
+			return GameState{}, errors.New("tiebreak needs a firstTo or bestOf format")
+		}
+		legsToWin, _ := req.Config.Format.limits(req.Config.Legs)
+		if tb.WinBy < 0 || tb.Cap < 0 {
+			return GameState{}, errors.New("tiebreak winBy and cap must be >= 0")
+		}
+		if legsToWin < 2 {
+			return GameState{}, errors.New("tiebreak needs sets of at least 2 legs to win")
+		}
+		if len(req.PlayerIDs) < 2 {
+			return GameState{}, errors.New("tiebreak needs at least two players")
+		}
+		if tb.Cap != 0 && tb.Cap <= legsToWin {
+			return GameState{}, fmt.Errorf("tiebreak cap must be above the %d legs needed to win a set", legsToWin)
+		}
+	}
+	if req.Config.Starter == "" {
+		req.Config.Starter = StarterAlternate
+	}
+	if !req.Config.Starter.valid() {
+		return GameState{}, errors.New("starter must be one of alternate, loser, winner")
+	}
+	if err := legacyCheckRule(&req.Config.InRule, req.Config.DoubleIn, "doubleIn", "inRule"); err != nil {
+		return GameState{}, err
+	}
+	if err := legacyCheckRule(&req.Config.OutRule, req.Config.DoubleOut, "doubleOut", "outRule"); err != nil {
+		return GameState{}, err
+	}
+	if req.Config.InRule == "" {
+		req.Config.InRule = RuleStraight
+	}
+	if req.Config.OutRule == "" {
+		req.Config.OutRule = RuleDouble
+	}
+	if !req.Config.InRule.valid() || !req.Config.OutRule.valid() {
+		return GameState{}, errors.New("inRule and outRule must be one of straight, double, master")
+	}
+	if len(req.StartingScores) > 0 && !gameMode.Schema().Handicaps {
+		return GameState{}, fmt.Errorf("startingScores are not supported for %s", mode)
+	}
+	if err := gameMode.ValidateConfig(req); err != nil {
+		return GameState{}, err
+	}
+
+	players := make([]GamePlayer, len(req.PlayerIDs))
+	for i, pid := range req.PlayerIDs {
+		players[i] = GamePlayer{ID: pid, Seat: i + 1}
+		if v, ok := req.StartingScores[pid]; ok {
+			players[i].StartingScore = &v
+		}
+	}
+	if err := gameMode.Setup(req, players); err != nil {
+		return GameState{}, err
+	}
 
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
@@ -53,20 +134,35 @@ func (r *Repository) CreateGame(ctx context.Context, req CreateGameRequest) (Gam
 	var createdAt time.Time
 
 	err = tx.QueryRow(ctx, `
-INSERT INTO games (mode, starting_score, legs, sets, double_out)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO games (mode, starting_score, legs, sets, format, tiebreak, starter, bull_off, in_rule, out_rule, double_in, double_out, options)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING id::text, created_at;
-`, mode, req.Config.StartingScore, req.Config.Legs, req.Config.Sets, req.Config.DoubleOut).
+`, mode, req.Config.StartingScore, req.Config.Legs, req.Config.Sets, req.Config.Format, req.Config.Tiebreak, req.Config.Starter, req.Config.BullOff, req.Config.InRule, req.Config.OutRule, req.Config.InRule == RuleDouble, req.Config.OutRule == RuleDouble, req.Config.ModeOptions).
 		Scan(&gameID, &createdAt)
 	if err != nil {
 		return GameState{}, err
 	}
 
-	for i, pid := range req.PlayerIDs {
+	teamIDs := make([]string, len(req.Teams))
+	for i, team := range req.Teams {
+		if err := tx.QueryRow(ctx, `
+INSERT INTO game_teams (game_id, name, seat)
+VALUES ($1, $2, $3)
+RETURNING id::text;
+`, gameID, strings.TrimSpace(team.Name), i+1).Scan(&teamIDs[i]); err != nil {
+			return GameState{}, err
+		}
+	}
+
+	for i, p := range players {
+		var teamID *string
+		if teamOf != nil {
+			teamID = &teamIDs[teamOf[i]]
+		}
 		if _, err := tx.Exec(ctx, `
-INSERT INTO game_players (game_id, player_id, seat)
-VALUES ($1, $2, $3);
-`, gameID, pid, i+1); err != nil {
+INSERT INTO game_players (game_id, player_id, seat, number, team_id, starting_score)
+VALUES ($1, $2, $3, $4, $5, $6);
+`, gameID, p.ID, p.Seat, p.Number, teamID, p.StartingScore); err != nil {
 			return GameState{}, err
 		}
 	}
@@ -105,7 +201,7 @@ func (r *Repository) ListGames(ctx context.Context, limit int) ([]Game, error) {
 	}
 
 	rows, err := r.db.Query(ctx, `
-SELECT id::text, mode, starting_score, legs, sets, double_out, status, created_at, winner_id
+SELECT id::text, mode, starting_score, legs, sets, format, tiebreak, starter, bull_off, in_rule, out_rule, options, status, created_at, winner_id, winner_team_id
 FROM games
 ORDER BY created_at DESC
 LIMIT $1;
@@ -119,7 +215,7 @@ LIMIT $1;
 	for rows.Next() {
 		var g Game
 		var startingScore *int
-		var winnerID *string
+		var winnerID, winnerTeamID *string
 
 		if err := rows.Scan(
 			&g.ID,
@@ -127,16 +223,25 @@ LIMIT $1;
 			&startingScore,
 			&g.Config.Legs,
 			&g.Config.Sets,
-			&g.Config.DoubleOut,
+			&g.Config.Format,
+			&g.Config.Tiebreak,
+			&g.Config.Starter,
+			&g.Config.BullOff,
+			&g.Config.InRule,
+			&g.Config.OutRule,
+			&g.Config.ModeOptions,
 			&g.Status,
 			&g.CreatedAt,
 			&winnerID,
+			&winnerTeamID,
 		); err != nil {
 			return nil, err
 		}
 
 		g.Config.StartingScore = startingScore
+		fillLegacyCheckFlags(&g.Config)
 		g.WinnerID = winnerID
+		g.WinnerTeamID = winnerTeamID
 		games = append(games, g)
 	}
 	if err := rows.Err(); err != nil {
@@ -150,6 +255,12 @@ LIMIT $1;
 			return nil, err
 		}
 		games[i].Players = players
+
+		teams, err := r.loadTeamsForGame(ctx, games[i].ID, players)
+		if err != nil {
+			return nil, err
+		}
+		games[i].Teams = teams
 	}
 
 	return games, nil
@@ -168,7 +279,7 @@ func (r *Repository) getGameState(ctx context.Context, gameID string) (GameState
 
 	// Load game row
 	err := r.db.QueryRow(ctx, `
-SELECT id::text, mode, starting_score, legs, sets, double_out, status, created_at, winner_id
+SELECT id::text, mode, starting_score, legs, sets, format, tiebreak, starter, bull_off, in_rule, out_rule, options, status, created_at, winner_id, winner_team_id, bull_off_result
 FROM games
 WHERE id = $1;
 `, gameID).Scan(
@@ -177,10 +288,18 @@ WHERE id = $1;
 		&startingScore,
 		&state.Config.Legs,
 		&state.Config.Sets,
-		&state.Config.DoubleOut,
+		&state.Config.Format,
+		&state.Config.Tiebreak,
+		&state.Config.Starter,
+		&state.Config.BullOff,
+		&state.Config.InRule,
+		&state.Config.OutRule,
+		&state.Config.ModeOptions,
 		&state.Status,
 		&state.CreatedAt,
 		&state.WinnerID,
+		&state.WinnerTeamID,
+		&state.BullOff,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -189,6 +308,7 @@ WHERE id = $1;
 		return GameState{}, err
 	}
 	state.Config.StartingScore = startingScore
+	fillLegacyCheckFlags(&state.Config)
 
 	// Load players (seating order)
 	players, err := r.loadPlayersForGame(ctx, state.ID)
@@ -197,9 +317,15 @@ WHERE id = $1;
 	}
 	state.Players = players
 
+	teams, err := r.loadTeamsForGame(ctx, state.ID, players)
+	if err != nil {
+		return GameState{}, err
+	}
+	state.Teams = teams
+
 	// Load throws history
 	trows, err := r.db.Query(ctx, `
-SELECT id::text, game_id::text, player_id::text, visit_score, darts_thrown, created_at
+SELECT id::text, game_id::text, player_id::text, visit_score, darts_thrown, darts, checkout_double, created_at
 FROM throws
 WHERE game_id = $1
 ORDER BY created_at ASC, id ASC;
@@ -218,6 +344,8 @@ ORDER BY created_at ASC, id ASC;
 			&t.PlayerID,
 			&t.VisitScore,
 			&t.DartsThrown,
+			&t.Darts,
+			&t.CheckoutDouble,
 			&t.CreatedAt,
 		); err != nil {
 			return GameState{}, err
@@ -237,7 +365,7 @@ ORDER BY created_at ASC, id ASC;
 // loadPlayersForGame loads the players for a single game (in seat order).
 func (r *Repository) loadPlayersForGame(ctx context.Context, gameID string) ([]GamePlayer, error) {
 	rows, err := r.db.Query(ctx, `
-SELECT p.id::text, p.name, gp.seat
+SELECT p.id::text, p.name, gp.seat, gp.number, gp.team_id::text, gp.starting_score
 FROM game_players gp
 JOIN players p ON p.id = gp.player_id
 WHERE gp.game_id = $1
@@ -251,7 +379,7 @@ ORDER BY gp.seat ASC;
 	players := make([]GamePlayer, 0)
 	for rows.Next() {
 		var gp GamePlayer
-		if err := rows.Scan(&gp.ID, &gp.Name, &gp.Seat); err != nil {
+		if err := rows.Scan(&gp.ID, &gp.Name, &gp.Seat, &gp.Number, &gp.TeamID, &gp.StartingScore); err != nil {
 			return nil, err
 		}
 		players = append(players, gp)
@@ -263,6 +391,41 @@ ORDER BY gp.seat ASC;
 	return players, nil
 }
 
+// loadTeamsForGame loads the teams of a team game (in seat order), with
+// their members in throwing order; nil for individual games.
+func (r *Repository) loadTeamsForGame(ctx context.Context, gameID string, players []GamePlayer) ([]GameTeam, error) {
+	rows, err := r.db.Query(ctx, `
+SELECT id::text, name, seat
+FROM game_teams
+WHERE game_id = $1
+ORDER BY seat ASC;
+`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []GameTeam
+	for rows.Next() {
+		var t GameTeam
+		if err := rows.Scan(&t.ID, &t.Name, &t.Seat); err != nil {
+			return nil, err
+		}
+		t.PlayerIDs = make([]string, 0)
+		for _, p := range players {
+			if p.TeamID != nil && *p.TeamID == t.ID {
+				t.PlayerIDs = append(t.PlayerIDs, p.ID)
+			}
+		}
+		teams = append(teams, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
 //
 // -----------------------------------------------------------------------------
 // Throws / scoring (X01 + undo)
@@ -274,12 +437,38 @@ func (r *Repository) AddThrow(ctx context.Context, gameID string, req CreateThro
 	if req.PlayerID == "" {
 		return GameState{}, errors.New("playerId is required")
 	}
+	if err := validateDarts(req.Darts); err != nil {
+		return GameState{}, err
+	}
+	if len(req.Darts) > 0 {
+		// Per-dart input: the visit total (and dart count) follow from the
+		// darts; a visitScore sent along has to agree with them.
+		if req.DartsThrown == 0 {
+			req.DartsThrown = len(req.Darts)
+		}
+		if len(req.Darts) != req.DartsThrown {
+			return GameState{}, errors.New("dartsThrown must match the number of darts")
+		}
+		total := 0
+		for _, d := range req.Darts {
+			total += d.Score()
+		}
+		if req.VisitScore != 0 && req.VisitScore != total {
+			return GameState{}, fmt.Errorf("visitScore %d does not match the darts (%d)", req.VisitScore, total)
+		}
+		req.VisitScore = total
+	}
 	if req.DartsThrown < 1 || req.DartsThrown > 3 {
 		return GameState{}, errors.New("dartsThrown must be between 1 and 3")
 	}
 	if req.VisitScore < 0 || req.VisitScore > 180 {
 		return GameState{}, errors.New("visitScore must be between 0 and 180")
 	}
+	if req.CheckoutDouble != nil {
+		if err := validateCheckoutDouble(req, *req.CheckoutDouble); err != nil {
+			return GameState{}, err
+		}
+	}
 
 	// Load current state to validate membership & turn / finished state.
 	stateBefore, err := r.getGameState(ctx, gameID)
@@ -290,6 +479,26 @@ func (r *Repository) AddThrow(ctx context.Context, gameID string, req CreateThro
 	if stateBefore.Status == "finished" {
 		return GameState{}, errors.New("game is already finished")
 	}
+	if stateBefore.Config.BullOff && stateBefore.BullOff == nil {
+		return GameState{}, errors.New("the bull-off has not been decided yet")
+	}
+	if requiresDarts(stateBefore.Config.Mode) && len(req.Darts) == 0 {
+		return GameState{}, fmt.Errorf("darts are required for %s", stateBefore.Config.Mode)
+	}
+	if req.CheckoutDouble != nil {
+		if !namesCheckouts(stateBefore.Config.Mode) {
+			return GameState{}, fmt.Errorf("checkoutDouble is not supported for %s", stateBefore.Config.Mode)
+		}
+		if s := scoreOf(&stateBefore, req.PlayerID); s == nil || s.Remaining == nil || *s.Remaining != req.VisitScore {
+			return GameState{}, errors.New("checkoutDouble is only for a visit that checks out")
+		}
+	}
+	if err := validateFinishingDart(&stateBefore, req); err != nil {
+		return GameState{}, err
+	}
+	if s := scoreOf(&stateBefore, req.PlayerID); s != nil && s.DartsLeft != nil && req.DartsThrown > *s.DartsLeft {
+		return GameState{}, fmt.Errorf("only %d darts are left in this attempt", *s.DartsLeft)
+	}
 
 	// Ensure player is part of this game
 	playerInGame := false
@@ -303,16 +512,18 @@ func (r *Repository) AddThrow(ctx context.Context, gameID string, req CreateThro
 		return GameState{}, errors.New("player is not part of this game")
 	}
 
-	// Enforce turn order: only currentPlayerId is allowed to throw
+	// Enforce turn order: only currentPlayerId is allowed to throw. It
+	// follows the leg/set starter policy, so a new leg must be opened by its
+	// starter rather than whoever sits after the last thrower.
 	if stateBefore.CurrentPlayerID != "" && stateBefore.CurrentPlayerID != req.PlayerID {
 		return GameState{}, fmt.Errorf("not this player's turn")
 	}
 
 	// Insert throw
 	_, err = r.db.Exec(ctx, `
-INSERT INTO throws (game_id, player_id, visit_score, darts_thrown)
-VALUES ($1, $2, $3, $4);
-`, gameID, req.PlayerID, req.VisitScore, req.DartsThrown)
+INSERT INTO throws (game_id, player_id, visit_score, darts_thrown, darts, checkout_double)
+VALUES ($1, $2, $3, $4, $5, $6);
+`, gameID, req.PlayerID, req.VisitScore, req.DartsThrown, req.Darts, req.CheckoutDouble)
 	if err != nil {
 		return GameState{}, err
 	}
@@ -367,6 +578,48 @@ WHERE id = $1;
 	return state, nil
 }
 
+// DecideBullOff records the pre-match bull-off of a game (see decideBullOff)
+// and returns the updated GameState, whose first leg is now started by the
+// winner. It can be re-submitted until the first throw.
+func (r *Repository) DecideBullOff(ctx context.Context, gameID string, req BullOffRequest) (GameState, error) {
+	state, err := r.getGameState(ctx, gameID)
+	if err != nil {
+		return GameState{}, err
+	}
+	if !state.Config.BullOff {
+		return GameState{}, errors.New("this game has no bull-off")
+	}
+	if len(state.History) > 0 {
+		return GameState{}, errors.New("the bull-off must be decided before the first throw")
+	}
+
+	for i := range req.Entries {
+		req.Entries[i].PlayerID = strings.TrimSpace(req.Entries[i].PlayerID)
+	}
+	bullOff, err := decideBullOff(&state, req.Entries, time.Now().UTC())
+	if err != nil {
+		return GameState{}, err
+	}
+
+	if _, err := r.db.Exec(ctx, `
+UPDATE games
+SET bull_off_result = $1
+WHERE id = $2;
+`, bullOff, gameID); err != nil {
+		return GameState{}, err
+	}
+
+	state, err = r.getGameState(ctx, gameID)
+	if err != nil {
+		return GameState{}, err
+	}
+	if err := r.syncGameStatus(ctx, &state); err != nil {
+		return GameState{}, err
+	}
+
+	return state, nil
+}
+
 // -----------------------
 // Match helpers (legs & sets)
 // -----------------------
@@ -399,6 +652,192 @@ func computeSetsWon(match *MatchScore) map[string]int {
 	return wins
 }
 
+// finishLeg records winner as the winner of the current leg and propagates
+// the result to the set and match according to the format; t is the
+// finishing throw. It reports whether the match is over (won or drawn).
+func finishLeg(match *MatchScore, winner string, t Throw) bool {
+	set := &match.Sets[match.CurrentSetIndex]
+	leg := &set.Legs[match.CurrentLegIndex]
+	at := t.CreatedAt
+
+	leg.WinnerID = &winner
+	leg.FinishedAt = &at
+	leg.finishedBy = t.ID
+
+	// Replayed legs have no winner and do not count as played.
+	legsWon := computeLegsWonInSet(set)
+	played := 0
+	for _, n := range legsWon {
+		played += n
+	}
+	setWinner, over := decide(legsWon, played, set.LegsToWin, set.LegsToPlay)
+	if match.Tiebreak != nil && set.Deciding {
+		setWinner, over = decideTiebreak(*match.Tiebreak, set, legsWon)
+	}
+	if !over {
+		return false
+	}
+	set.WinnerID = setWinner
+	set.Drawn = setWinner == nil
+	set.FinishedAt = &at
+
+	matchWinner, over := decide(computeSetsWon(match), len(match.Sets), match.SetsToWin, match.SetsToPlay)
+	if !over {
+		return false
+	}
+	match.WinnerID = matchWinner
+	match.Drawn = matchWinner == nil
+	match.FinishedAt = &at
+	return true
+}
+
+// replayLeg closes the current leg without a winner, to be played again;
+// t is the throw that ended it.
+func replayLeg(match *MatchScore, t Throw) {
+	leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+	at := t.CreatedAt
+	leg.Result = LegResultReplay
+	leg.FinishedAt = &at
+	leg.finishedBy = t.ID
+}
+
+// winMatch ends the match on the spot, whatever the format: winner takes
+// the current leg, its set and the match; t is the winning throw.
+func winMatch(match *MatchScore, winner string, t Throw) {
+	set := &match.Sets[match.CurrentSetIndex]
+	leg := &set.Legs[match.CurrentLegIndex]
+	at := t.CreatedAt
+
+	leg.WinnerID = &winner
+	leg.FinishedAt = &at
+	leg.finishedBy = t.ID
+	set.WinnerID = &winner
+	set.FinishedAt = &at
+	match.WinnerID = &winner
+	match.FinishedAt = &at
+}
+
+// newMatchScore builds the initial match structure: 1 set, 1 leg, started
+// by the bull-off winner or else the first seat.
+func newMatchScore(state *GameState, start int) MatchScore {
+	config, players := state.Config, state.Players
+
+	format := config.Format
+	if format == "" {
+		format = FormatFirstTo
+	}
+	legsToWin, legsToPlay := format.limits(config.Legs)
+	setsToWin, setsToPlay := format.limits(config.Sets)
+
+	initialScoresByPlayer := make(map[string]int, len(players))
+	for _, p := range players {
+		initialScoresByPlayer[p.ID] = start
+	}
+
+	starter := config.Starter
+	if starter == "" {
+		starter = StarterAlternate
+	}
+
+	// A tiebreak needs two sides and sets of more than one leg.
+	tiebreak := config.Tiebreak
+	if len(players) < 2 || legsToWin < 2 {
+		tiebreak = nil
+	}
+
+	var firstStarter string
+	switch {
+	case state.BullOff != nil:
+		firstStarter = state.BullOff.StarterID
+	case len(players) > 0:
+		firstStarter = players[0].ID
+	}
+
+	firstLeg := LegScore{
+		LegNumber:      1,
+		StartingScore:  start,
+		StarterID:      firstStarter,
+		ScoresByPlayer: initialScoresByPlayer,
+	}
+
+	firstSet := SetScore{
+		SetNumber:  1,
+		StarterID:  firstStarter,
+		LegsToWin:  legsToWin,
+		LegsToPlay: legsToPlay,
+		Legs:       []LegScore{firstLeg},
+	}
+
+	match := MatchScore{
+		Format:          format,
+		Starter:         starter,
+		Tiebreak:        tiebreak,
+		SetsToWin:       setsToWin,
+		SetsToPlay:      setsToPlay,
+		CurrentSetIndex: 0,
+		CurrentLegIndex: 0,
+		Sets:            []SetScore{firstSet},
+	}
+	markDecidingSet(&match)
+	return match
+}
+
+// nextPlayerID returns the player after whoever threw last; if there are no
+// throws, the first player.
+func nextPlayerID(state *GameState, playerIndex map[string]int) string {
+	if len(state.History) == 0 {
+		return state.Players[0].ID
+	}
+	last := state.History[len(state.History)-1]
+	if lastIdx, ok := playerIndex[last.PlayerID]; ok {
+		nextIdx := (lastIdx + 1) % len(state.Players)
+		return state.Players[nextIdx].ID
+	}
+	return state.Players[0].ID
+}
+
+// nextActiveIndex returns the first player after idx (in seat order) for
+// whom active is true, wrapping around; idx itself if there is none.
+func nextActiveIndex(n, idx int, active func(i int) bool) int {
+	for step := 1; step <= n; step++ {
+		i := (idx + step) % n
+		if active(i) {
+			return i
+		}
+	}
+	return idx
+}
+
+// soleHighest returns the index of the single highest score, or -1 if the
+// lead is shared.
+func soleHighest(scores []int) int {
+	return soleLeader(scores, func(a, b int) bool { return a > b })
+}
+
+// soleLowest returns the index of the single lowest score, or -1 if the
+// lead is shared.
+func soleLowest(scores []int) int {
+	return soleLeader(scores, func(a, b int) bool { return a < b })
+}
+
+func soleLeader(scores []int, better func(a, b int) bool) int {
+	best := -1
+	tied := false
+	for i, s := range scores {
+		switch {
+		case best == -1 || better(s, scores[best]):
+			best = i
+			tied = false
+		case s == scores[best]:
+			tied = true
+		}
+	}
+	if tied {
+		return -1
+	}
+	return best
+}
+
 // startNextLegOrSet is used during reconstruction (computeScores) to decide
 // whether to create a new leg in the same set or start a new set.
 func startNextLegOrSet(match *MatchScore, start int, players []GamePlayer) {
@@ -417,25 +856,30 @@ func startNextLegOrSet(match *MatchScore, start int, players []GamePlayer) {
 		return mp
 	}
 
-	if currentSet.WinnerID != nil {
-		// Start a NEW SET
+	if currentSet.FinishedAt != nil {
+		// Start a NEW SET (the last one was won or drawn)
 		newSetNumber := len(match.Sets) + 1
+		starter := nextStarter(match, players, true)
 
 		newLeg := LegScore{
 			LegNumber:      1,
 			StartingScore:  start,
+			StarterID:      starter,
 			ScoresByPlayer: newScoresByPlayer(),
 		}
 
 		newSet := SetScore{
-			SetNumber: newSetNumber,
-			LegsToWin: currentSet.LegsToWin,
-			Legs:      []LegScore{newLeg},
+			SetNumber:  newSetNumber,
+			StarterID:  starter,
+			LegsToWin:  currentSet.LegsToWin,
+			LegsToPlay: currentSet.LegsToPlay,
+			Legs:       []LegScore{newLeg},
 		}
 
 		match.Sets = append(match.Sets, newSet)
 		match.CurrentSetIndex = len(match.Sets) - 1
 		match.CurrentLegIndex = 0
+		markDecidingSet(match)
 	} else {
 		// NEW LEG in the SAME SET
 		newLegNumber := len(currentSet.Legs) + 1
@@ -443,6 +887,7 @@ func startNextLegOrSet(match *MatchScore, start int, players []GamePlayer) {
 		newLeg := LegScore{
 			LegNumber:      newLegNumber,
 			StartingScore:  start,
+			StarterID:      nextStarter(match, players, false),
 			ScoresByPlayer: newScoresByPlayer(),
 		}
 
@@ -452,15 +897,48 @@ func startNextLegOrSet(match *MatchScore, start int, players []GamePlayer) {
 	}
 }
 
-// computeScores fills state.Scores, state.CurrentPlayerID, and (for X01)
-// reconstructs the full legs/sets MatchScore from history.
-//
-// X01 rules implemented:
-//   - Per-leg starting score (default 501 if not provided)
-//   - Bust if result < 0
-//   - If double-out is enabled, result == 1 is a bust (cannot finish on 1)
-//   - Reaching 0 finishes the leg; legs aggregate into sets; sets into match
+// requiresDarts reports whether mode is scored from per-dart input rather
+// than visit totals.
+func requiresDarts(mode string) bool {
+	m, ok := LookupMode(mode)
+	return ok && m.Schema().Darts
+}
+
+// validateFinishingDart rejects darts thrown after the one that brings the
+// player's remaining score to 0 in a mode that counts down: the visit ended
+// there, and the stored visit total must not include them.
+func validateFinishingDart(state *GameState, req CreateThrowRequest) error {
+	s := scoreOf(state, req.PlayerID)
+	if len(req.Darts) == 0 || !namesCheckouts(state.Config.Mode) || s == nil || s.Remaining == nil {
+		return nil
+	}
+	darts := req.Darts
+	if s.Opened != nil && !*s.Opened {
+		// Only darts from the opening dart onward count.
+		_, darts, _ = openingScore(Throw{Darts: req.Darts}, state.Config.InRule)
+	}
+	if i := finishingDart(*s.Remaining, darts); i >= 0 && i < len(darts)-1 {
+		n := len(req.Darts) - len(darts) + i + 1
+		return fmt.Errorf("dart %d already reaches 0; leave out the darts after it", n)
+	}
+	return nil
+}
+
+// namesCheckouts reports whether mode takes a checkoutDouble with visit
+// totals.
+func namesCheckouts(mode string) bool {
+	m, ok := LookupMode(mode)
+	return ok && m.Schema().Checkouts
+}
+
+// computeScores fills state.Scores, state.CurrentPlayerID, the legs/sets
+// MatchScore and the winner by playing the history, throw by throw,
+// through the game's registered GameMode (see mode.go).
 func (r *Repository) computeScores(state *GameState) {
+	if len(state.Teams) > 0 {
+		r.computeTeamScores(state)
+		return
+	}
 	if len(state.Players) == 0 {
 		state.Scores = []PlayerScore{}
 		state.CurrentPlayerID = ""
@@ -468,226 +946,57 @@ func (r *Repository) computeScores(state *GameState) {
 		return
 	}
 
-	// Map playerID → index
-	playerIndex := make(map[string]int, len(state.Players))
-	for i, p := range state.Players {
-		playerIndex[p.ID] = i
-	}
-
-	// Initialize scores with one entry per player.
-	scores := make([]PlayerScore, len(state.Players))
-	for i, p := range state.Players {
-		scores[i] = PlayerScore{
-			PlayerID: p.ID,
+	m, ok := LookupMode(state.Config.Mode)
+	if !ok {
+		// Unknown (legacy) mode: keep simple scoring (no legs/sets)
+		playerIndex := make(map[string]int, len(state.Players))
+		scores := make([]PlayerScore, len(state.Players))
+		for i, p := range state.Players {
+			playerIndex[p.ID] = i
+			scores[i] = PlayerScore{PlayerID: p.ID}
 		}
-	}
-
-	// Non-X01 modes: keep simple scoring for now (no legs/sets)
-	if state.Config.Mode != "X01" {
 		state.Scores = scores
-
-		if len(state.History) == 0 {
-			state.CurrentPlayerID = state.Players[0].ID
-		} else {
-			last := state.History[len(state.History)-1]
-			if lastIdx, ok := playerIndex[last.PlayerID]; ok {
-				nextIdx := (lastIdx + 1) % len(state.Players)
-				state.CurrentPlayerID = state.Players[nextIdx].ID
-			} else {
-				state.CurrentPlayerID = state.Players[0].ID
-			}
-		}
-
+		state.CurrentPlayerID = nextPlayerID(state, playerIndex)
 		state.MatchScore = nil
 		return
 	}
 
-	// ------------- X01 with legs & sets --------------
-
-	start := 501
-	if state.Config.StartingScore != nil {
-		start = *state.Config.StartingScore
-	}
-
-	legsToWin := state.Config.Legs
-	if legsToWin <= 0 {
-		legsToWin = 1
-	}
-	setsToWin := state.Config.Sets
-	if setsToWin <= 0 {
-		setsToWin = 1
-	}
-
-	// Initial match structure: 1 set, 1 leg
-	initialScoresByPlayer := make(map[string]int, len(state.Players))
-	for _, p := range state.Players {
-		initialScoresByPlayer[p.ID] = start
-	}
-
-	firstLeg := LegScore{
-		LegNumber:      1,
-		StartingScore:  start,
-		ScoresByPlayer: initialScoresByPlayer,
-	}
-
-	firstSet := SetScore{
-		SetNumber: 1,
-		LegsToWin: legsToWin,
-		Legs:      []LegScore{firstLeg},
-	}
-
-	match := MatchScore{
-		SetsToWin:       setsToWin,
-		CurrentSetIndex: 0,
-		CurrentLegIndex: 0,
-		Sets:            []SetScore{firstSet},
-	}
-
-	// Per-player remaining for the CURRENT leg
-	remaining := make([]int, len(state.Players))
-	for i := range remaining {
-		remaining[i] = start
-		v := remaining[i]
-		scores[i].Remaining = &v
-		scores[i].LastVisit = nil
-		scores[i].LastThree = nil
-	}
-
-	var matchWinnerID *string
-
-	for _, t := range state.History {
-		if matchWinnerID != nil {
-			// ignore any garbage throws after match finish (shouldn't exist)
-			break
-		}
-
-		idx, ok := playerIndex[t.PlayerID]
-		if !ok {
-			continue
-		}
-
-		set := &match.Sets[match.CurrentSetIndex]
-		leg := &set.Legs[match.CurrentLegIndex]
-
-		// If leg already finished, next throw starts a new leg or set.
-		if leg.WinnerID != nil {
-			startNextLegOrSet(&match, start, state.Players)
-			set = &match.Sets[match.CurrentSetIndex]
-			leg = &set.Legs[match.CurrentLegIndex]
-
-			// Reset per-player remaining & last visit for new leg
-			for i := range remaining {
-				remaining[i] = start
-				v := remaining[i]
-				scores[i].Remaining = &v
-				scores[i].LastVisit = nil
-				scores[i].LastThree = nil
-			}
-		}
-
-		cur := leg.ScoresByPlayer[t.PlayerID]
-		if cur == 0 {
-			// In case the map got out of sync, fall back to tracked remaining
-			cur = remaining[idx]
-			if cur == 0 {
-				cur = start
-			}
-		}
-
-		cand := cur - t.VisitScore
-
-		// Bust rules:
-		// - result < 0 => bust
-		// - if double-out and result == 1 => bust
-		if cand < 0 || (state.Config.DoubleOut && cand == 1) {
-			// bust: ignore this visit for scoring, don't change remaining
-			continue
-		}
-
-		// Accept the visit
-		leg.ScoresByPlayer[t.PlayerID] = cand
-		remaining[idx] = cand
-
-		visit := t.VisitScore
-		scores[idx].LastVisit = &visit
-		v := remaining[idx]
-		scores[idx].Remaining = &v
-
-		// Checkout: leg finished
-		if cand == 0 {
-			now := t.CreatedAt
-			winner := t.PlayerID
-			leg.WinnerID = &winner
-			leg.FinishedAt = &now
-
-			// Did this checkout also win the set / match?
-			legsWon := computeLegsWonInSet(set)
-			if legsWon[winner] >= set.LegsToWin {
-				set.WinnerID = &winner
-				set.FinishedAt = &now
-
-				setsWon := computeSetsWon(&match)
-				if setsWon[winner] >= match.SetsToWin {
-					matchWinnerID = &winner
-					break
-				}
-			}
-
-			// ✅ IMPORTANT: advance immediately to next leg/set (if match not finished)
-			if matchWinnerID == nil {
-				startNextLegOrSet(&match, start, state.Players)
-
-				// Reset per-player remaining & last visit for the new leg
-				for i := range remaining {
-					remaining[i] = start
-					v := remaining[i]
-					scores[i].Remaining = &v
-					scores[i].LastVisit = nil
-					scores[i].LastThree = nil
-				}
-			}
-		}
+	play := m.Start(state)
+	for i := range state.History {
+		play.ApplyThrow(&state.History[i])
 	}
+	play.Report()
+	state.CurrentPlayerID = play.CurrentPlayer()
+	state.WinnerID = play.Winner()
 
-	state.Scores = scores
-	state.MatchScore = &match
-	state.WinnerID = matchWinnerID
-
-	// Determine current player based on last throw; if no throws, first player.
-	if len(state.History) == 0 {
-		state.CurrentPlayerID = state.Players[0].ID
-	} else {
-		last := state.History[len(state.History)-1]
-		if lastIdx, ok := playerIndex[last.PlayerID]; ok {
-			nextIdx := (lastIdx + 1) % len(state.Players)
-			state.CurrentPlayerID = state.Players[nextIdx].ID
-		} else {
-			state.CurrentPlayerID = state.Players[0].ID
-		}
-	}
+	// Whatever the mode, a fresh leg is opened by its starter.
+	applyLegStarter(state)
 }
 
 // syncGameStatus updates the games.status and games.winner_id fields
 // based on reconstructed match state:
-// - finished: some player has enough sets to win the match
-// - in_progress: at least one throw and no winner
+// - finished: the match is won or drawn under its format
+// - bull_off: the bull-off is still to be decided (config.bullOff)
+// - in_progress: at least one throw and no result
 // - pending: no throws
+//
+// Legs are awarded during reconstruction by each mode's own finish rule
+// (checkout in X01, lowest score in Cut-throat, rounds completed in
+// HighScore, ...); the format then decides sets and the match. The winner
+// is read from that result, so no mode needs its own check here: the
+// low-score win of Cut-throat is already applied by startCricket.
 func (r *Repository) syncGameStatus(ctx context.Context, state *GameState) error {
 	// Determine winner (if any).
 	var winnerID *string
+	drawn := false
 
 	if state.MatchScore != nil {
-		// Prefer match-level winner (sets/legs)
-		setsWon := computeSetsWon(state.MatchScore)
-		for pid, cnt := range setsWon {
-			if cnt >= state.MatchScore.SetsToWin {
-				id := pid
-				winnerID = &id
-				break
-			}
-		}
+		// Prefer match-level result (sets/legs, per the format)
+		winnerID = state.MatchScore.WinnerID
+		drawn = state.MatchScore.Drawn
 	} else {
-		// Fallback for non-X01 (or legacy): someone with remaining == 0
+		// Fallback for unknown (legacy) modes, which have no MatchScore:
+		// someone with remaining == 0
 		for _, s := range state.Scores {
 			if s.Remaining != nil && *s.Remaining == 0 {
 				id := s.PlayerID
@@ -697,9 +1006,18 @@ func (r *Repository) syncGameStatus(ctx context.Context, state *GameState) error
 		}
 	}
 
+	// In team games the match is won by a team (games.winner_team_id).
+	var winnerTeamID *string
+	if len(state.Teams) > 0 {
+		winnerTeamID, winnerID = winnerID, nil
+	}
+
 	var newStatus string
-	if winnerID != nil {
+	if winnerID != nil || winnerTeamID != nil || drawn {
+		// A drawn match is finished without a winner.
 		newStatus = "finished"
+	} else if state.Config.BullOff && state.BullOff == nil {
+		newStatus = "bull_off"
 	} else if len(state.History) > 0 {
 		newStatus = "in_progress"
 	} else {
@@ -707,26 +1025,44 @@ func (r *Repository) syncGameStatus(ctx context.Context, state *GameState) error
 	}
 
 	// Check if status or winner changed.
-	sameWinner := false
-	if state.WinnerID == nil && winnerID == nil {
-		sameWinner = true
-	} else if state.WinnerID != nil && winnerID != nil && *state.WinnerID == *winnerID {
-		sameWinner = true
-	}
-
-	if newStatus == state.Status && sameWinner {
+	if newStatus == state.Status && sameID(state.WinnerID, winnerID) && sameID(state.WinnerTeamID, winnerTeamID) {
 		return nil
 	}
 
 	if _, err := r.db.Exec(ctx, `
 UPDATE games
-SET status = $1, winner_id = $2
-WHERE id = $3;
-`, newStatus, winnerID, state.ID); err != nil {
+SET status = $1, winner_id = $2, winner_team_id = $3
+WHERE id = $4;
+`, newStatus, winnerID, winnerTeamID, state.ID); err != nil {
 		return err
 	}
 
 	state.Status = newStatus
 	state.WinnerID = winnerID
+	state.WinnerTeamID = winnerTeamID
+	return nil
+}
+
+// scoreOf returns the score of playerID, or of their team in team games.
+func scoreOf(state *GameState, playerID string) *PlayerScore {
+	side := playerID
+	for _, p := range state.Players {
+		if p.ID == playerID && p.TeamID != nil {
+			side = *p.TeamID
+		}
+	}
+	for i := range state.Scores {
+		if state.Scores[i].PlayerID == side {
+			return &state.Scores[i]
+		}
+	}
 	return nil
 }
+
+// sameID reports whether two optional IDs are equal.
+func sameID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testVisit is one visit of a test game: the thrower and either their darts
+// in short notation (see parseDart) or, without darts, a visit total.
+type testVisit struct {
+	player string
+	darts  []string
+	total  int
+}
+
+// v is a visit with darts, in short notation.
+func v(player string, darts ...string) testVisit {
+	return testVisit{player: player, darts: darts}
+}
+
+// vt is a visit entered as a total.
+func vt(player string, total int) testVisit {
+	return testVisit{player: player, total: total}
+}
+
+// repeat returns visits n times over, e.g. n rounds of the same misses.
+func repeat(n int, visits ...testVisit) []testVisit {
+	var out []testVisit
+	for range n {
+		out = append(out, visits...)
+	}
+	return out
+}
+
+// testDarts parses darts in short notation.
+func testDarts(t *testing.T, specs ...string) []Dart {
+	t.Helper()
+	var darts []Dart
+	for _, s := range specs {
+		d, err := parseDart(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		darts = append(darts, d)
+	}
+	return darts
+}
+
+// testGame returns a game of config between players (with their Killer
+// numbers 1, 2, ... in seat order) after the given visits, scored.
+func testGame(t *testing.T, config GameConfig, players []string, visits []testVisit) *GameState {
+	t.Helper()
+	state := &GameState{Config: config}
+	for i, id := range players {
+		number := i + 1
+		state.Players = append(state.Players, GamePlayer{ID: id, Seat: i + 1, Number: &number})
+	}
+	for _, vis := range visits {
+		addVisit(t, state, vis)
+	}
+	(&Repository{}).computeScores(state)
+	return state
+}
+
+// addVisit appends vis to the game's history, unscored.
+func addVisit(t *testing.T, state *GameState, vis testVisit) {
+	t.Helper()
+	throw := Throw{ID: fmt.Sprintf("t%03d", len(state.History)), PlayerID: vis.player, VisitScore: vis.total, DartsThrown: 3}
+	if len(vis.darts) > 0 {
+		throw.Darts = testDarts(t, vis.darts...)
+		throw.DartsThrown = len(throw.Darts)
+		throw.VisitScore = 0
+		for _, d := range throw.Darts {
+			throw.VisitScore += d.Score()
+		}
+	}
+	state.History = append(state.History, throw)
+}
+
+// legEndTest is a game whose last visit ends its first leg.
+type legEndTest struct {
+	name    string
+	config  GameConfig
+	players []string
+	visits  []testVisit
+	want    string // the leg's winner
+}
+
+// testLegEnd checks that nobody has won the first leg of each game before
+// its last visit, and that the last visit hands it to the expected player.
+// Games are played over two legs so a leg win does not end the match.
+func testLegEnd(t *testing.T, tests []legEndTest) {
+	t.Helper()
+	for _, tt := range tests {
+		tt.config.Legs = 2
+		last := len(tt.visits) - 1
+
+		before := testGame(t, tt.config, tt.players, tt.visits[:last])
+		if got := firstLegWinner(before); got != "" {
+			t.Errorf("%s: leg won by %q before the last visit", tt.name, got)
+		}
+		after := testGame(t, tt.config, tt.players, tt.visits)
+		if got := firstLegWinner(after); got != tt.want {
+			t.Errorf("%s: leg won by %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// firstLegWinner returns who won the first leg of the match, or "".
+func firstLegWinner(state *GameState) string {
+	return deref(state.MatchScore.Sets[0].Legs[0].WinnerID)
+}
+
+// deref returns *s, or "" for nil.
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// intPtr returns a pointer to n.
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,123 @@
+package game
+
+import "errors"
+
+// defaultShanghaiRounds is the classic Shanghai length (numbers 1 to 7).
+const defaultShanghaiRounds = 7
+
+// numberForRound returns the number in play for a (1-based) round of a
+// "round N targets number N" game. Rounds past 20 wrap back to 1.
+func numberForRound(round int) int {
+	return (round-1)%20 + 1
+}
+
+// isShanghai reports whether a visit holds a single, a double and a treble
+// of target.
+func isShanghai(darts []Dart, target int) bool {
+	var single, double, treble bool
+	for _, d := range darts {
+		if d.IsMiss() || d.Segment != target {
+			continue
+		}
+		switch d.Multiplier {
+		case 1:
+			single = true
+		case 2:
+			double = true
+		case 3:
+			treble = true
+		}
+	}
+	return single && double && treble
+}
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "Shanghai",
+			Description: "Round N targets number N; a single, double and treble of it in one visit wins the match outright.",
+			Darts:       true,
+			Teams:       true,
+			Options: []ModeOption{
+				{Key: "rounds", Type: "int", Default: defaultShanghaiRounds, Description: "number of rounds, 1 to 20 (0 for the default)"},
+			},
+		},
+		validate: func(req CreateGameRequest) error {
+			if req.Config.Rounds < 0 || req.Config.Rounds > 20 {
+				return errors.New("rounds must be between 1 and 20, or 0 for the default")
+			}
+			return nil
+		},
+		start: startShanghai,
+	})
+}
+
+// startShanghai scores a Shanghai match.
+//
+// Rules implemented:
+//   - Round N targets number N; only darts in that number score, at face
+//     value times the multiplier
+//   - A visit with a single, double and treble of the round's number
+//     ("Shanghai") wins the whole match on the spot, even mid-round and
+//     with legs or sets still to play
+//   - Otherwise the highest total after the configured rounds (default 7)
+//     wins the leg; a tie is played off with extra rounds
+func startShanghai(g *play) {
+	state, scores := g.state, g.scores
+	numRounds := state.Config.Rounds
+	if numRounds <= 0 {
+		numRounds = defaultShanghaiRounds
+	}
+
+	match := newMatchScore(state, 0)
+	g.match = &match
+
+	// Per-player points and rounds played in the CURRENT leg
+	points := make([]int, len(state.Players))
+	rounds := newLegRounds(len(state.Players), numRounds)
+	resetLeg := func() {
+		for i := range points {
+			points[i] = 0
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+		}
+		rounds.reset()
+	}
+	resetLeg()
+
+	g.apply = func(t *Throw, idx int) {
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+
+		target := numberForRound(rounds.play(idx))
+
+		visitPoints := 0
+		for _, d := range t.Darts {
+			if !d.IsMiss() && d.Segment == target {
+				visitPoints += d.Score()
+			}
+		}
+		points[idx] += visitPoints
+		leg.ScoresByPlayer[t.PlayerID] = points[idx]
+		scores[idx].LastVisit = &visitPoints
+
+		if isShanghai(t.Darts, target) {
+			winMatch(&match, t.PlayerID, *t)
+			return
+		}
+
+		if rounds.settle(&match, *t, 0, state.Players, points, soleHighest) {
+			resetLeg()
+		}
+	}
+
+	g.report = func() {
+		for i := range scores {
+			p := points[i]
+			scores[i].Points = &p
+			target := numberForRound(rounds.next(i))
+			scores[i].Target = &target
+		}
+		round := rounds.current()
+		state.Round = &round
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+import "testing"
+
+func TestIsShanghai(t *testing.T) {
+	tests := []struct {
+		darts  []string
+		target int
+		want   bool
+	}{
+		{darts: []string{"S3", "D3", "T3"}, target: 3, want: true},
+		{darts: []string{"T3", "S3", "D3"}, target: 3, want: true},
+		{darts: []string{"S3", "D3", "T4"}, target: 3},
+		{darts: []string{"S3", "S3", "T3"}, target: 3},
+		{darts: []string{"S3", "D3"}, target: 3},
+	}
+	for _, tt := range tests {
+		if got := isShanghai(testDarts(t, tt.darts...), tt.target); got != tt.want {
+			t.Errorf("isShanghai(%v, %d) = %t, want %t", tt.darts, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestShanghaiLegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "highest after the rounds",
+			config:  GameConfig{Mode: "Shanghai", ModeOptions: ModeOptions{Rounds: 2}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "S1"), v("b", "D1"), v("a", "T2"), v("b", "S2")},
+			want:    "a",
+		},
+		{
+			name:    "tie played off",
+			config:  GameConfig{Mode: "Shanghai", ModeOptions: ModeOptions{Rounds: 1}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "D1"), v("b", "D1"), v("a", "M"), v("b", "S2")},
+			want:    "b",
+		},
+		{
+			name:    "Shanghai",
+			config:  GameConfig{Mode: "Shanghai", ModeOptions: ModeOptions{Rounds: 2}},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "S1"), v("b", "S1", "D1", "T1")},
+			want:    "b",
+		},
+	})
+}
+
+func TestShanghaiWinsMatch(t *testing.T) {
+	config := GameConfig{Mode: "Shanghai", Legs: 3, Sets: 2}
+	state := testGame(t, config, []string{"a", "b"}, []testVisit{v("a", "S1"), v("b", "S1", "D1", "T1")})
+
+	match := state.MatchScore
+	if match.FinishedAt == nil || deref(state.WinnerID) != "b" {
+		t.Fatalf("match finished %t, won by %q, want finished, won by b", match.FinishedAt != nil, deref(state.WinnerID))
+	}
+	if got := deref(match.Sets[0].WinnerID); got != "b" {
+		t.Errorf("set won by %q, want b", got)
+	}
+	if len(match.Sets) != 1 {
+		t.Errorf("%d sets, want 1", len(match.Sets))
+	}
+}
@@ -0,0 +1,69 @@
+package game
+
+// valid reports whether s is one of the known starter policies.
+func (s Starter) valid() bool {
+	switch s {
+	case StarterAlternate, StarterLoser, StarterWinner:
+		return true
+	}
+	return false
+}
+
+// nextStarter returns who starts the leg that follows the current one, by
+// the match's starter policy; newSet is true when that leg opens a new set.
+func nextStarter(match *MatchScore, players []GamePlayer, newSet bool) string {
+	set := &match.Sets[match.CurrentSetIndex]
+	leg := &set.Legs[match.CurrentLegIndex]
+
+	after := func(id string) string {
+		for i, p := range players {
+			if p.ID == id {
+				return players[(i+1)%len(players)].ID
+			}
+		}
+		return players[0].ID
+	}
+
+	switch {
+	case match.Starter == StarterWinner && leg.WinnerID != nil:
+		return *leg.WinnerID
+	case match.Starter == StarterLoser && leg.WinnerID != nil:
+		return after(*leg.WinnerID)
+	case newSet:
+		return after(set.StarterID)
+	default:
+		return after(leg.StarterID)
+	}
+}
+
+// applyLegStarter hands the turn to the starter of the current leg if
+// nobody has thrown in it yet; within a leg, the mode's own turn order
+// stands.
+func applyLegStarter(state *GameState) {
+	match := state.MatchScore
+	if match == nil || match.FinishedAt != nil {
+		return
+	}
+	set := &match.Sets[match.CurrentSetIndex]
+	leg := &set.Legs[match.CurrentLegIndex]
+	if leg.StarterID == "" {
+		return
+	}
+
+	var prev *LegScore
+	switch {
+	case match.CurrentLegIndex > 0:
+		prev = &set.Legs[match.CurrentLegIndex-1]
+	case match.CurrentSetIndex > 0:
+		prevSet := &match.Sets[match.CurrentSetIndex-1]
+		prev = &prevSet.Legs[len(prevSet.Legs)-1]
+	}
+
+	fresh := len(state.History) == 0
+	if prev != nil && len(state.History) > 0 {
+		fresh = state.History[len(state.History)-1].ID == prev.finishedBy
+	}
+	if fresh {
+		state.CurrentPlayerID = leg.StarterID
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+import "testing"
+
+func TestNextStarter(t *testing.T) {
+	players := []GamePlayer{{ID: "a", Seat: 1}, {ID: "b", Seat: 2}, {ID: "c", Seat: 3}}
+	tests := []struct {
+		name       string
+		starter    Starter
+		setStarter string
+		legStarter string
+		legWinner  string // "" = no winner (replayed or drawn leg)
+		newSet     bool
+		want       string
+	}{
+		{name: "alternate", starter: StarterAlternate, setStarter: "a", legStarter: "b", legWinner: "a", want: "c"},
+		{name: "alternate wraps", starter: StarterAlternate, setStarter: "a", legStarter: "c", legWinner: "a", want: "a"},
+		{name: "alternate new set", starter: StarterAlternate, setStarter: "a", legStarter: "c", legWinner: "c", newSet: true, want: "b"},
+		{name: "winner", starter: StarterWinner, setStarter: "a", legStarter: "a", legWinner: "c", want: "c"},
+		{name: "winner new set", starter: StarterWinner, setStarter: "a", legStarter: "a", legWinner: "b", newSet: true, want: "b"},
+		{name: "loser", starter: StarterLoser, setStarter: "a", legStarter: "a", legWinner: "b", want: "c"},
+		{name: "loser wraps", starter: StarterLoser, setStarter: "a", legStarter: "a", legWinner: "c", want: "a"},
+		{name: "winner without a winner", starter: StarterWinner, setStarter: "a", legStarter: "b", want: "c"},
+		{name: "loser without a winner", starter: StarterLoser, setStarter: "a", legStarter: "c", newSet: true, want: "b"},
+	}
+	for _, tt := range tests {
+		leg := LegScore{StarterID: tt.legStarter}
+		if tt.legWinner != "" {
+			leg.WinnerID = &tt.legWinner
+		}
+		match := MatchScore{
+			Starter: tt.starter,
+			Sets:    []SetScore{{StarterID: tt.setStarter, Legs: []LegScore{leg}}},
+		}
+		if got := nextStarter(&match, players, tt.newSet); got != tt.want {
+			t.Errorf("%s: nextStarter = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLegStarterTurn(t *testing.T) {
+	tests := []struct {
+		name    string
+		starter Starter
+		legs    int
+		visits  []testVisit
+		want    string
+	}{
+		{name: "first leg", starter: StarterAlternate, legs: 3, want: "a"},
+		{name: "within a leg", starter: StarterAlternate, legs: 3, visits: []testVisit{vt("a", 10)}, want: "b"},
+		{name: "alternate after a's leg", starter: StarterAlternate, legs: 3, visits: []testVisit{vt("a", 40)}, want: "b"},
+		{name: "alternate after b's leg", starter: StarterAlternate, legs: 3, visits: []testVisit{vt("a", 10), vt("b", 40)}, want: "b"},
+		{name: "winner starts", starter: StarterWinner, legs: 3, visits: []testVisit{vt("a", 40)}, want: "a"},
+		{name: "seat after the winner starts", starter: StarterLoser, legs: 3, visits: []testVisit{vt("a", 10), vt("b", 40)}, want: "c"},
+		{name: "third leg alternates back", starter: StarterAlternate, legs: 3, visits: []testVisit{vt("a", 40), vt("b", 40)}, want: "c"},
+	}
+	for _, tt := range tests {
+		config := GameConfig{Mode: "X01", StartingScore: intPtr(40), OutRule: RuleStraight, Legs: tt.legs, Starter: tt.starter}
+		state := testGame(t, config, []string{"a", "b", "c"}, tt.visits)
+		if state.CurrentPlayerID != tt.want {
+			t.Errorf("%s: current player %q, want %q", tt.name, state.CurrentPlayerID, tt.want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// target is a configurable board target, written as:
+//
+//	"20"        any segment of 20
+//	"S20"       single 20 ("D20" double, "T20" treble)
+//	"D", "T"    any double, any treble
+//	"25"        outer bull
+//	"Bull"      either bull
+type target struct {
+	Label      string
+	Segment    int // 0 = any segment
+	Multiplier int // 0 = any ring
+}
+
+// parseTarget reads a target. Its label is normalised to upper case ("d7"
+// reads "D7"), or "Bull" for the bull, so the same target always shows the
+// same label.
+func parseTarget(s string) (target, error) {
+	spec := strings.ToUpper(strings.TrimSpace(s))
+	label := spec
+
+	switch spec {
+	case "BULL":
+		return target{Label: "Bull", Segment: BullSegment}, nil
+	case "25":
+		return target{Label: label, Segment: BullSegment, Multiplier: 1}, nil
+	case "D":
+		return target{Label: label, Multiplier: 2}, nil
+	case "T":
+		return target{Label: label, Multiplier: 3}, nil
+	}
+
+	multiplier := 0
+	switch {
+	case strings.HasPrefix(spec, "S"):
+		multiplier = 1
+	case strings.HasPrefix(spec, "D"):
+		multiplier = 2
+	case strings.HasPrefix(spec, "T"):
+		multiplier = 3
+	}
+	if multiplier != 0 {
+		spec = spec[1:]
+	}
+
+	n, err := strconv.Atoi(spec)
+	if err != nil || n < 1 || n > 20 {
+		return target{}, fmt.Errorf("invalid target %q", s)
+	}
+	return target{Label: label, Segment: n, Multiplier: multiplier}, nil
+}
+
+func parseTargets(specs []string) ([]target, error) {
+	targets := make([]target, 0, len(specs))
+	for _, s := range specs {
+		t, err := parseTarget(s)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// Matches reports whether d hits the target.
+func (t target) Matches(d Dart) bool {
+	if d.IsMiss() {
+		return false
+	}
+	if t.Segment != 0 && d.Segment != t.Segment {
+		return false
+	}
+	if t.Multiplier != 0 && d.Multiplier != t.Multiplier {
+		return false
+	}
+	return true
+}
+
+// specificity ranks how narrowly t picks out darts: a segment in one ring
+// (2), a number or the bull (1), or a whole ring such as "any double" (0).
+func (t target) specificity() int {
+	switch {
+	case t.Segment != 0 && t.Multiplier != 0:
+		return 2
+	case t.Segment != 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// validateTargets checks the configured targets of a targets-based mode.
+func validateTargets(req CreateGameRequest) error {
+	_, err := parseTargets(req.Config.Targets)
+	return err
+}
+
+// validateMarksTargets checks the target set of a marks-based mode: unlike
+// a Halve-It sheet, it cannot list the same target twice.
+func validateMarksTargets(req CreateGameRequest) error {
+	targets, err := parseTargets(req.Config.Targets)
+	if err != nil {
+		return err
+	}
+	seen := make(map[[2]int]string, len(targets))
+	for _, t := range targets {
+		key := [2]int{t.Segment, t.Multiplier}
+		if first, ok := seen[key]; ok {
+			if first == t.Label {
+				return fmt.Errorf("target %q is listed twice", t.Label)
+			}
+			return fmt.Errorf("targets %q and %q are the same", first, t.Label)
+		}
+		seen[key] = t.Label
+	}
+	return nil
+}
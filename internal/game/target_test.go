@@ -0,0 +1,87 @@
+package game
+
+import "testing"
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    target
+		wantErr bool
+	}{
+		{in: "20", want: target{Label: "20", Segment: 20}},
+		{in: "S20", want: target{Label: "S20", Segment: 20, Multiplier: 1}},
+		{in: "D7", want: target{Label: "D7", Segment: 7, Multiplier: 2}},
+		{in: "T10", want: target{Label: "T10", Segment: 10, Multiplier: 3}},
+		{in: "D", want: target{Label: "D", Multiplier: 2}},
+		{in: "d", want: target{Label: "D", Multiplier: 2}},
+		{in: " t ", want: target{Label: "T", Multiplier: 3}},
+		{in: "d7", want: target{Label: "D7", Segment: 7, Multiplier: 2}},
+		{in: "T", want: target{Label: "T", Multiplier: 3}},
+		{in: "25", want: target{Label: "25", Segment: BullSegment, Multiplier: 1}},
+		{in: "bull", want: target{Label: "Bull", Segment: BullSegment}},
+		{in: "21", wantErr: true},
+		{in: "D25", wantErr: true},
+		{in: "X", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseTarget(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseTarget(%q) error = %v, wantErr %t", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseTarget(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTargetMatches(t *testing.T) {
+	tests := []struct {
+		target, dart string
+		want         bool
+	}{
+		{target: "20", dart: "T20", want: true},
+		{target: "20", dart: "S19"},
+		{target: "D7", dart: "D7", want: true},
+		{target: "D7", dart: "S7"},
+		{target: "D", dart: "D3", want: true},
+		{target: "D", dart: "Bull", want: true},
+		{target: "T", dart: "S3"},
+		{target: "25", dart: "25", want: true},
+		{target: "25", dart: "Bull"},
+		{target: "Bull", dart: "25", want: true},
+		{target: "Bull", dart: "Bull", want: true},
+		{target: "20", dart: "M"},
+	}
+	for _, tt := range tests {
+		tgt, err := parseTarget(tt.target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tgt.Matches(testDarts(t, tt.dart)[0]); got != tt.want {
+			t.Errorf("%s matches %s = %t, want %t", tt.target, tt.dart, got, tt.want)
+		}
+	}
+}
+
+func TestValidateMarksTargets(t *testing.T) {
+	tests := []struct {
+		targets []string
+		wantErr bool
+	}{
+		{targets: nil},
+		{targets: []string{"20", "19", "Bull"}},
+		{targets: []string{"20", "D20", "D", "Bull", "25"}},
+		{targets: []string{"20", "20"}, wantErr: true},
+		{targets: []string{"D", "d"}, wantErr: true},
+		{targets: []string{"Bull", "BULL"}, wantErr: true},
+		{targets: []string{"20", "X"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		req := CreateGameRequest{Config: GameConfig{ModeOptions: ModeOptions{Targets: tt.targets}}}
+		if err := validateMarksTargets(req); (err != nil) != tt.wantErr {
+			t.Errorf("%v: error = %v, wantErr %t", tt.targets, err, tt.wantErr)
+		}
+	}
+}
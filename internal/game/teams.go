@@ -0,0 +1,114 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// teamSeating validates the teams of a CreateGameRequest and returns every
+// player in seating order, alternating between teams (A1, B1, A2, B2, ...),
+// together with the index of each player's team.
+func teamSeating(teams []CreateTeamRequest) ([]string, []int, error) {
+	if len(teams) < 2 {
+		return nil, nil, errors.New("a team game needs at least two teams")
+	}
+
+	seen := make(map[string]bool)
+	longest := 0
+	for i, team := range teams {
+		if strings.TrimSpace(team.Name) == "" {
+			return nil, nil, fmt.Errorf("team %d: name is required", i+1)
+		}
+		if len(team.PlayerIDs) == 0 {
+			return nil, nil, fmt.Errorf("team %d: at least one player is required", i+1)
+		}
+		for _, pid := range team.PlayerIDs {
+			if seen[pid] {
+				return nil, nil, fmt.Errorf("player %s is in more than one team", pid)
+			}
+			seen[pid] = true
+		}
+		longest = max(longest, len(team.PlayerIDs))
+	}
+
+	playerIDs := make([]string, 0, len(seen))
+	teamOf := make([]int, 0, len(seen))
+	for round := 0; round < longest; round++ {
+		for i, team := range teams {
+			if round < len(team.PlayerIDs) {
+				playerIDs = append(playerIDs, team.PlayerIDs[round])
+				teamOf = append(teamOf, i)
+			}
+		}
+	}
+	return playerIDs, teamOf, nil
+}
+
+// computeTeamScores scores a team game: the mode's scoring runs with every
+// team as a single side (so Scores, LegScore.ScoresByPlayer and the turn
+// order are keyed by team ID), while History keeps the individual players.
+// The current player is the next member, in team order, of the team whose
+// turn it is.
+func (r *Repository) computeTeamScores(state *GameState) {
+	teamOf := make(map[string]string, len(state.Players))
+	for _, p := range state.Players {
+		if p.TeamID != nil {
+			teamOf[p.ID] = *p.TeamID
+		}
+	}
+
+	sides := *state
+	sides.Teams = nil
+	sides.Players = make([]GamePlayer, len(state.Teams))
+	for i, team := range state.Teams {
+		sides.Players[i] = GamePlayer{ID: team.ID, Name: team.Name, Seat: team.Seat}
+		// Teammates share their starting score (see validateStartingScores).
+		for _, p := range state.Players {
+			if p.TeamID != nil && *p.TeamID == team.ID {
+				sides.Players[i].StartingScore = p.StartingScore
+				break
+			}
+		}
+	}
+	sides.History = make([]Throw, len(state.History))
+	for i, t := range state.History {
+		t.PlayerID = teamOf[t.PlayerID]
+		sides.History[i] = t
+	}
+
+	r.computeScores(&sides)
+
+	for i := range state.History {
+		state.History[i].KnockedBack = sides.History[i].KnockedBack
+	}
+	for i := range sides.Scores {
+		teamID := sides.Scores[i].PlayerID
+		sides.Scores[i].TeamID = &teamID
+	}
+
+	players, teams, history := state.Players, state.Teams, state.History
+	*state = sides
+	state.Players, state.Teams, state.History = players, teams, history
+	state.WinnerTeamID, state.WinnerID = sides.WinnerID, nil
+	state.CurrentPlayerID = nextTeamMember(state, sides.CurrentPlayerID)
+}
+
+// nextTeamMember returns the member of teamID who throws next: the one after
+// the member who threw last for the team, or the first member.
+func nextTeamMember(state *GameState, teamID string) string {
+	for _, team := range state.Teams {
+		if team.ID != teamID || len(team.PlayerIDs) == 0 {
+			continue
+		}
+		for h := len(state.History) - 1; h >= 0; h-- {
+			for i, pid := range team.PlayerIDs {
+				if pid == state.History[h].PlayerID {
+					return team.PlayerIDs[(i+1)%len(team.PlayerIDs)]
+				}
+			}
+		}
+		return team.PlayerIDs[0]
+	}
+	return ""
+}
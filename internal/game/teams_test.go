@@ -0,0 +1,152 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTeamSeating(t *testing.T) {
+	tests := []struct {
+		name        string
+		teams       []CreateTeamRequest
+		wantPlayers []string
+		wantTeamOf  []int
+		wantErr     bool
+	}{
+		{
+			name:        "pairs alternate",
+			teams:       []CreateTeamRequest{{Name: "A", PlayerIDs: []string{"a1", "a2"}}, {Name: "B", PlayerIDs: []string{"b1", "b2"}}},
+			wantPlayers: []string{"a1", "b1", "a2", "b2"},
+			wantTeamOf:  []int{0, 1, 0, 1},
+		},
+		{
+			name:        "uneven teams",
+			teams:       []CreateTeamRequest{{Name: "A", PlayerIDs: []string{"a1"}}, {Name: "B", PlayerIDs: []string{"b1", "b2", "b3"}}},
+			wantPlayers: []string{"a1", "b1", "b2", "b3"},
+			wantTeamOf:  []int{0, 1, 1, 1},
+		},
+		{name: "one team", teams: []CreateTeamRequest{{Name: "A", PlayerIDs: []string{"a1", "a2"}}}, wantErr: true},
+		{name: "no name", teams: []CreateTeamRequest{{Name: " ", PlayerIDs: []string{"a1"}}, {Name: "B", PlayerIDs: []string{"b1"}}}, wantErr: true},
+		{name: "no players", teams: []CreateTeamRequest{{Name: "A"}, {Name: "B", PlayerIDs: []string{"b1"}}}, wantErr: true},
+		{name: "player in two teams", teams: []CreateTeamRequest{{Name: "A", PlayerIDs: []string{"x"}}, {Name: "B", PlayerIDs: []string{"x"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		players, teamOf, err := teamSeating(tt.teams)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %t", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(players, tt.wantPlayers) || !slices.Equal(teamOf, tt.wantTeamOf) {
+			t.Errorf("%s: seating %v, teams %v, want %v, %v", tt.name, players, teamOf, tt.wantPlayers, tt.wantTeamOf)
+		}
+	}
+}
+
+// testTeamGame returns a game of config between teams, their members seated
+// as teamSeating does, after the given visits, scored.
+func testTeamGame(t *testing.T, config GameConfig, teams []GameTeam, visits []testVisit) *GameState {
+	t.Helper()
+	requests := make([]CreateTeamRequest, len(teams))
+	for i, team := range teams {
+		requests[i] = CreateTeamRequest{Name: team.ID, PlayerIDs: team.PlayerIDs}
+	}
+	playerIDs, teamOf, err := teamSeating(requests)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &GameState{Config: config, Teams: teams}
+	for i, pid := range playerIDs {
+		teamID := teams[teamOf[i]].ID
+		state.Players = append(state.Players, GamePlayer{ID: pid, Seat: i + 1, TeamID: &teamID})
+	}
+	for i := range state.Teams {
+		state.Teams[i].Seat = i + 1
+	}
+	for _, vis := range visits {
+		addVisit(t, state, vis)
+	}
+	(&Repository{}).computeScores(state)
+	return state
+}
+
+func TestTeamRotation(t *testing.T) {
+	config := GameConfig{Mode: "X01", StartingScore: intPtr(501), Legs: 2}
+	pairs := func() []GameTeam {
+		return []GameTeam{{ID: "A", PlayerIDs: []string{"a1", "a2"}}, {ID: "B", PlayerIDs: []string{"b1", "b2"}}}
+	}
+	uneven := func() []GameTeam {
+		return []GameTeam{{ID: "A", PlayerIDs: []string{"a1", "a2"}}, {ID: "B", PlayerIDs: []string{"b1"}}}
+	}
+	tests := []struct {
+		name   string
+		teams  []GameTeam
+		visits []testVisit
+		want   string
+	}{
+		{name: "first member first", teams: pairs(), want: "a1"},
+		{name: "then the other team", teams: pairs(), visits: []testVisit{vt("a1", 60)}, want: "b1"},
+		{name: "then the next member", teams: pairs(), visits: []testVisit{vt("a1", 60), vt("b1", 60)}, want: "a2"},
+		{name: "round and round", teams: pairs(), visits: []testVisit{vt("a1", 60), vt("b1", 60), vt("a2", 60), vt("b2", 60)}, want: "a1"},
+		{name: "a lone player throws every team turn", teams: uneven(), visits: []testVisit{vt("a1", 60), vt("b1", 60), vt("a2", 60)}, want: "b1"},
+		{name: "the pair keeps alternating", teams: uneven(), visits: []testVisit{vt("a1", 60), vt("b1", 60), vt("a2", 60), vt("b1", 60)}, want: "a1"},
+	}
+	for _, tt := range tests {
+		state := testTeamGame(t, config, tt.teams, tt.visits)
+		if state.CurrentPlayerID != tt.want {
+			t.Errorf("%s: current player %q, want %q", tt.name, state.CurrentPlayerID, tt.want)
+		}
+	}
+}
+
+func TestTeamScores(t *testing.T) {
+	teams := []GameTeam{{ID: "A", PlayerIDs: []string{"a1", "a2"}}, {ID: "B", PlayerIDs: []string{"b1", "b2"}}}
+	config := GameConfig{Mode: "X01", StartingScore: intPtr(101), Legs: 1, Sets: 1}
+	state := testTeamGame(t, config, teams, []testVisit{vt("a1", 60), vt("b1", 20), vt("a2", 41)})
+
+	if len(state.Scores) != 2 {
+		t.Fatalf("%d scores, want one per team", len(state.Scores))
+	}
+	for i, want := range []struct {
+		team      string
+		remaining int
+	}{{"A", 0}, {"B", 81}} {
+		score := state.Scores[i]
+		if score.PlayerID != want.team || deref(score.TeamID) != want.team || *score.Remaining != want.remaining {
+			t.Errorf("score %d: %s (team %q) on %d, want %s on %d", i, score.PlayerID, deref(score.TeamID), *score.Remaining, want.team, want.remaining)
+		}
+	}
+	if deref(state.WinnerTeamID) != "A" || state.WinnerID != nil {
+		t.Errorf("won by team %q, player %q, want team A", deref(state.WinnerTeamID), deref(state.WinnerID))
+	}
+	if got := state.History[2].PlayerID; got != "a2" {
+		t.Errorf("history keeps the thrower %q, want a2", got)
+	}
+}
+
+func TestNextTeamMember(t *testing.T) {
+	state := &GameState{
+		Teams: []GameTeam{{ID: "A", PlayerIDs: []string{"a1", "a2", "a3"}}, {ID: "B", PlayerIDs: []string{"b1"}}},
+	}
+	tests := []struct {
+		name    string
+		history []string
+		team    string
+		want    string
+	}{
+		{name: "nobody has thrown", team: "A", want: "a1"},
+		{name: "after the last member to throw", history: []string{"a1", "b1", "a2", "b1"}, team: "A", want: "a3"},
+		{name: "wraps", history: []string{"a3", "b1"}, team: "A", want: "a1"},
+		{name: "single member", history: []string{"a1", "b1"}, team: "B", want: "b1"},
+		{name: "unknown team", team: "C", want: ""},
+	}
+	for _, tt := range tests {
+		state.History = nil
+		for _, pid := range tt.history {
+			state.History = append(state.History, Throw{PlayerID: pid})
+		}
+		if got := nextTeamMember(state, tt.team); got != tt.want {
+			t.Errorf("%s: nextTeamMember = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
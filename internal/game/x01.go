@@ -0,0 +1,254 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+func init() {
+	RegisterMode(funcMode{
+		schema: ModeSchema{
+			Name:        "X01",
+			Description: "Count down from the starting score; the out-rule decides the finishing dart.",
+			Teams:       true,
+			Handicaps:   true,
+			Checkouts:   true,
+			Options: []ModeOption{
+				{Key: "startingScore", Type: "int", Default: 501, Description: "score every player starts the leg on (per-player startingScores for handicaps)"},
+				{Key: "inRule", Type: "string", Default: RuleStraight, Values: []any{RuleStraight, RuleDouble, RuleMaster}, Description: "dart needed before scoring starts"},
+				{Key: "outRule", Type: "string", Default: RuleDouble, Values: []any{RuleStraight, RuleDouble, RuleMaster}, Description: "dart needed to finish the leg"},
+				{Key: "noBullFinish", Type: "bool", Default: false, Description: "the inner bull does not count as a double for the out-rule"},
+				{Key: "maxVisits", Type: "int", Default: 0, Description: "visits per player per leg, 0 for no limit"},
+				{Key: "onCap", Type: "string", Default: "lowest", Values: []any{"lowest", "replay"}, Description: "what happens when nobody checks out within maxVisits"},
+			},
+		},
+		validate: validateX01Config,
+		start:    startX01,
+	})
+}
+
+// validateX01Config checks the visit-limit options and the handicaps.
+func validateX01Config(req CreateGameRequest) error {
+	if req.Config.MaxVisits < 0 {
+		return errors.New("maxVisits must be >= 0")
+	}
+	if req.Config.OnCap != "" && req.Config.OnCap != "lowest" && req.Config.OnCap != "replay" {
+		return errors.New("onCap must be lowest or replay")
+	}
+	return validateStartingScores(req)
+}
+
+// validateStartingScores checks per-player starting scores (handicaps).
+// Teammates share a score, so they must share a starting score too.
+func validateStartingScores(req CreateGameRequest) error {
+	inGame := make(map[string]bool, len(req.PlayerIDs))
+	for _, pid := range req.PlayerIDs {
+		inGame[pid] = true
+	}
+	for pid, v := range req.StartingScores {
+		if !inGame[pid] {
+			return fmt.Errorf("starting score given for player %s who is not in the game", pid)
+		}
+		if v < 2 {
+			return fmt.Errorf("starting score for player %s must be > 1", pid)
+		}
+	}
+
+	for _, team := range req.Teams {
+		first, firstOK := req.StartingScores[team.PlayerIDs[0]]
+		for _, pid := range team.PlayerIDs[1:] {
+			v, ok := req.StartingScores[pid]
+			if ok != firstOK || v != first {
+				return fmt.Errorf("team %s: all players need the same starting score", team.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// startX01 scores an X01 match.
+//
+// Rules implemented:
+//   - Per-leg starting score (default 501 if not provided), optionally
+//     overridden per player as a handicap
+//   - In-rule (straight/double/master): nothing counts until the opening dart
+//   - Bust if result < 0
+//   - Unless straight-out, result == 1 is a bust (cannot finish on 1)
+//   - The finishing dart must satisfy the out-rule (the inner bull counts
+//     as a double unless noBullFinish); it is checked with per-dart data,
+//     or with a visit total that names its checkoutDouble
+//   - Reaching 0 finishes the leg; legs aggregate into sets; sets into match
+//   - With maxVisits, a leg nobody checks out in time goes to the lowest
+//     remaining, or is replayed (see capLeg)
+func startX01(g *play) {
+	state, scores := g.state, g.scores
+	start := 501
+	if state.Config.StartingScore != nil {
+		start = *state.Config.StartingScore
+	}
+
+	inRule := state.Config.InRule
+	if inRule == "" {
+		inRule = RuleStraight
+	}
+	outRule := finishRule{rule: state.Config.OutRule, noBull: state.Config.NoBullFinish}
+	if outRule.rule == "" {
+		outRule.rule = RuleDouble
+	}
+
+	// Per-player starting scores (handicaps)
+	starts := make([]int, len(state.Players))
+	handicapped := false
+	for i, p := range state.Players {
+		starts[i] = start
+		if p.StartingScore != nil {
+			starts[i] = *p.StartingScore
+			handicapped = handicapped || starts[i] != start
+		}
+	}
+	applyStarts := func(leg *LegScore) {
+		if !handicapped {
+			return
+		}
+		leg.StartingScoresByPlayer = make(map[string]int, len(state.Players))
+		for i, p := range state.Players {
+			leg.ScoresByPlayer[p.ID] = starts[i]
+			leg.StartingScoresByPlayer[p.ID] = starts[i]
+		}
+	}
+
+	match := newMatchScore(state, start)
+	g.match = &match
+	applyStarts(&match.Sets[0].Legs[0])
+
+	// Per-player remaining, in-rule status and visits for the CURRENT leg
+	remaining := make([]int, len(state.Players))
+	opened := make([]bool, len(state.Players))
+	visits := make([]int, len(state.Players))
+	resetLeg := func() {
+		for i := range remaining {
+			remaining[i] = starts[i]
+			v := remaining[i]
+			scores[i].Remaining = &v
+			scores[i].LastVisit = nil
+			scores[i].LastThree = nil
+			opened[i] = inRule == RuleStraight
+			visits[i] = 0
+		}
+	}
+	resetLeg()
+
+	nextLeg := func() {
+		startNextLegOrSet(&match, start, state.Players)
+		applyStarts(&match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex])
+		resetLeg()
+	}
+
+	// Visit-limited legs: once every player has thrown maxVisits visits
+	// without a checkout, the lowest remaining wins the leg, or (onCap
+	// "replay", or a tie for the lowest) the leg is replayed. capLeg applies
+	// that after t.
+	maxVisits := state.Config.MaxVisits
+	capLeg := func(t *Throw) {
+		if maxVisits <= 0 {
+			return
+		}
+		for _, v := range visits {
+			if v < maxVisits {
+				return
+			}
+		}
+
+		leg := &match.Sets[match.CurrentSetIndex].Legs[match.CurrentLegIndex]
+		winnerIdx := soleLowest(remaining)
+		if state.Config.OnCap == "replay" || winnerIdx == -1 {
+			replayLeg(&match, *t)
+			nextLeg()
+			return
+		}
+		leg.Result = LegResultCap
+		if !finishLeg(&match, state.Players[winnerIdx].ID, *t) {
+			nextLeg()
+		}
+	}
+
+	g.apply = func(t *Throw, idx int) {
+		set := &match.Sets[match.CurrentSetIndex]
+		leg := &set.Legs[match.CurrentLegIndex]
+
+		// If leg already finished, next throw starts a new leg or set.
+		if leg.WinnerID != nil {
+			nextLeg()
+			set = &match.Sets[match.CurrentSetIndex]
+			leg = &set.Legs[match.CurrentLegIndex]
+		}
+		visits[idx]++
+
+		cur := leg.ScoresByPlayer[t.PlayerID]
+		if cur == 0 {
+			// In case the map got out of sync, fall back to tracked remaining
+			cur = remaining[idx]
+			if cur == 0 {
+				cur = starts[idx]
+			}
+		}
+
+		visitScore, darts := t.VisitScore, t.Darts
+		if !opened[idx] {
+			visitScore, darts, opened[idx] = openingScore(*t, inRule)
+			if !opened[idx] {
+				// still waiting for the opening dart: the visit scores nothing
+				zero := 0
+				scores[idx].LastVisit = &zero
+				capLeg(t)
+				return
+			}
+		}
+
+		// Bust rules (see x01Remaining):
+		// - result < 0 => bust
+		// - unless straight-out, result == 1 => bust
+		// - the finishing dart (per-dart data, or checkoutDouble with a
+		//   visit total) must satisfy the out-rule
+		cand, ok := x01Remaining(cur, visitScore, darts, t.CheckoutDouble, outRule)
+		if !ok {
+			// bust: ignore this visit for scoring, don't change remaining
+			capLeg(t)
+			return
+		}
+
+		// Accept the visit
+		leg.ScoresByPlayer[t.PlayerID] = cand
+		remaining[idx] = cand
+
+		visit := cur - cand
+		scores[idx].LastVisit = &visit
+		v := remaining[idx]
+		scores[idx].Remaining = &v
+
+		// Checkout: leg finished
+		if cand == 0 {
+			leg.Result = LegResultCheckout
+
+			// Did this checkout also win the set / match?
+			if finishLeg(&match, t.PlayerID, *t) {
+				return
+			}
+
+			// ✅ IMPORTANT: advance immediately to next leg/set (if match not finished)
+			nextLeg()
+			return
+		}
+
+		capLeg(t)
+	}
+
+	g.report = func() {
+		if inRule != RuleStraight {
+			for i := range scores {
+				o := opened[i]
+				scores[i].Opened = &o
+			}
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestOpeningScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       CheckRule
+		darts      []string
+		total      int // visit total, without darts
+		want       int
+		wantDarts  []string
+		wantOpened bool
+	}{
+		{name: "double opens", rule: RuleDouble, darts: []string{"D20", "T20", "S5"}, want: 105, wantDarts: []string{"D20", "T20", "S5"}, wantOpened: true},
+		{name: "counts from the opening dart", rule: RuleDouble, darts: []string{"T20", "D10", "S5"}, want: 25, wantDarts: []string{"D10", "S5"}, wantOpened: true},
+		{name: "no double", rule: RuleDouble, darts: []string{"T20", "S20", "M"}},
+		{name: "bull opens", rule: RuleDouble, darts: []string{"M", "Bull"}, want: 50, wantDarts: []string{"Bull"}, wantOpened: true},
+		{name: "master opens on a treble", rule: RuleMaster, darts: []string{"S1", "T20"}, want: 60, wantDarts: []string{"T20"}, wantOpened: true},
+		{name: "master, single only", rule: RuleMaster, darts: []string{"S20", "S20"}},
+		{name: "visit total opens", rule: RuleDouble, total: 45, want: 45, wantOpened: true},
+		{name: "zero visit total", rule: RuleDouble, total: 0},
+	}
+	for _, tt := range tests {
+		throw := Throw{VisitScore: tt.total}
+		if len(tt.darts) > 0 {
+			throw.Darts = testDarts(t, tt.darts...)
+		}
+		got, darts, opened := openingScore(throw, tt.rule)
+		if got != tt.want || opened != tt.wantOpened {
+			t.Errorf("%s: openingScore = %d, %t, want %d, %t", tt.name, got, opened, tt.want, tt.wantOpened)
+		}
+		if wantDarts := testDarts(t, tt.wantDarts...); !slices.Equal(darts, wantDarts) {
+			t.Errorf("%s: opening darts = %v, want %v", tt.name, darts, wantDarts)
+		}
+	}
+}
+
+func TestX01DoubleIn(t *testing.T) {
+	config := GameConfig{Mode: "X01", StartingScore: intPtr(501), InRule: RuleDouble}
+	tests := []struct {
+		name          string
+		visits        []testVisit
+		wantRemaining int
+		wantOpened    bool
+	}{
+		{name: "not yet open", visits: []testVisit{v("a", "T20", "T20", "S20")}, wantRemaining: 501},
+		{name: "opened mid-visit", visits: []testVisit{v("a", "T20", "D20", "S20")}, wantRemaining: 441, wantOpened: true},
+		{name: "open stays open", visits: []testVisit{v("a", "D1", "M", "M"), v("b", "M"), v("a", "T20")}, wantRemaining: 439, wantOpened: true},
+	}
+	for _, tt := range tests {
+		state := testGame(t, config, []string{"a", "b"}, tt.visits)
+		score := scoreOf(state, "a")
+		if *score.Remaining != tt.wantRemaining || *score.Opened != tt.wantOpened {
+			t.Errorf("%s: remaining %d, opened %t, want %d, %t", tt.name, *score.Remaining, *score.Opened, tt.wantRemaining, tt.wantOpened)
+		}
+	}
+}
+
+func TestX01LegEnd(t *testing.T) {
+	testLegEnd(t, []legEndTest{
+		{
+			name:    "double-out checkout",
+			config:  GameConfig{Mode: "X01", StartingScore: intPtr(100)},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "T20"), v("b", "T20"), v("a", "S20"), v("b", "M"), v("a", "D10")},
+			want:    "a",
+		},
+		{
+			name:    "straight-out checkout",
+			config:  GameConfig{Mode: "X01", StartingScore: intPtr(100), OutRule: RuleStraight},
+			players: []string{"a", "b"},
+			visits:  []testVisit{v("a", "T20"), v("b", "T20"), v("a", "S20", "S20")},
+			want:    "a",
+		},
+		{
+			name:    "checkout as a visit total",
+			config:  GameConfig{Mode: "X01", StartingScore: intPtr(101)},
+			players: []string{"a", "b"},
+			visits:  []testVisit{vt("a", 60), vt("b", 101)},
+			want:    "b",
+		},
+	})
+}
+
+func TestValidateStartingScores(t *testing.T) {
+	players := []string{"a1", "b1", "a2", "b2"}
+	teams := []CreateTeamRequest{{Name: "A", PlayerIDs: []string{"a1", "a2"}}, {Name: "B", PlayerIDs: []string{"b1", "b2"}}}
+	tests := []struct {
+		name    string
+		scores  map[string]int
+		teams   []CreateTeamRequest
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "some players", scores: map[string]int{"a1": 301, "b2": 701}},
+		{name: "lowest", scores: map[string]int{"a1": 2}},
+		{name: "too low", scores: map[string]int{"a1": 1}, wantErr: true},
+		{name: "not in the game", scores: map[string]int{"x": 301}, wantErr: true},
+		{name: "teammates agree", scores: map[string]int{"a1": 301, "a2": 301}, teams: teams},
+		{name: "teammates differ", scores: map[string]int{"a1": 301, "a2": 401}, teams: teams, wantErr: true},
+		{name: "one teammate only", scores: map[string]int{"a1": 301}, teams: teams, wantErr: true},
+	}
+	for _, tt := range tests {
+		req := CreateGameRequest{PlayerIDs: players, StartingScores: tt.scores, Teams: tt.teams}
+		if err := validateStartingScores(req); (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestX01Handicaps(t *testing.T) {
+	config := GameConfig{Mode: "X01", StartingScore: intPtr(501), OutRule: RuleStraight, Legs: 2}
+	tests := []struct {
+		name       string
+		starts     map[string]int
+		visits     []testVisit
+		wantLeg    int // index of the current leg
+		wantStarts map[string]int
+		wantScores map[string]int
+	}{
+		{
+			name:       "no handicap",
+			visits:     []testVisit{vt("a", 100)},
+			wantScores: map[string]int{"a": 401, "b": 501},
+		},
+		{
+			name:       "handicap",
+			starts:     map[string]int{"b": 301},
+			wantStarts: map[string]int{"a": 501, "b": 301},
+			wantScores: map[string]int{"a": 501, "b": 301},
+		},
+		{
+			name:       "handicap in the next leg",
+			starts:     map[string]int{"b": 301},
+			visits:     []testVisit{vt("a", 180), vt("b", 180), vt("a", 100), vt("b", 121)},
+			wantLeg:    1,
+			wantStarts: map[string]int{"a": 501, "b": 301},
+			wantScores: map[string]int{"a": 501, "b": 301},
+		},
+	}
+	for _, tt := range tests {
+		state := &GameState{Config: config}
+		for i, id := range []string{"a", "b"} {
+			p := GamePlayer{ID: id, Seat: i + 1}
+			if s, ok := tt.starts[id]; ok {
+				p.StartingScore = intPtr(s)
+			}
+			state.Players = append(state.Players, p)
+		}
+		for _, vis := range tt.visits {
+			addVisit(t, state, vis)
+		}
+		(&Repository{}).computeScores(state)
+
+		match := state.MatchScore
+		if match.CurrentLegIndex != tt.wantLeg {
+			t.Errorf("%s: on leg %d, want %d", tt.name, match.CurrentLegIndex, tt.wantLeg)
+			continue
+		}
+		leg := match.Sets[0].Legs[tt.wantLeg]
+		if !maps.Equal(leg.StartingScoresByPlayer, tt.wantStarts) {
+			t.Errorf("%s: leg starts %v, want %v", tt.name, leg.StartingScoresByPlayer, tt.wantStarts)
+		}
+		for id, want := range tt.wantScores {
+			if got := *scoreOf(state, id).Remaining; got != want || leg.ScoresByPlayer[id] != want {
+				t.Errorf("%s: %s on %d (leg %d), want %d", tt.name, id, got, leg.ScoresByPlayer[id], want)
+			}
+		}
+	}
+}
+
+func TestX01VisitLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		onCap      string
+		visits     []testVisit
+		wantResult LegResult
+		wantWinner string
+		wantLegs   int // legs in the first set
+	}{
+		{name: "before the cap", visits: []testVisit{vt("a", 60), vt("b", 20), vt("a", 20)}, wantLegs: 1},
+		{name: "lowest remaining wins", visits: []testVisit{vt("a", 60), vt("b", 20), vt("a", 20), vt("b", 20)}, wantResult: LegResultCap, wantWinner: "a", wantLegs: 2},
+		{name: "tie for the lowest is replayed", visits: []testVisit{vt("a", 50), vt("b", 50), vt("a", 10), vt("b", 10)}, wantResult: LegResultReplay, wantLegs: 2},
+		{name: "replay on cap", onCap: "replay", visits: []testVisit{vt("a", 60), vt("b", 20), vt("a", 20), vt("b", 20)}, wantResult: LegResultReplay, wantLegs: 2},
+		{name: "checkout within the cap", visits: []testVisit{vt("a", 60), vt("b", 101)}, wantResult: LegResultCheckout, wantWinner: "b", wantLegs: 2},
+	}
+	for _, tt := range tests {
+		config := GameConfig{Mode: "X01", StartingScore: intPtr(101), Legs: 2, ModeOptions: ModeOptions{MaxVisits: 2, OnCap: tt.onCap}}
+		state := testGame(t, config, []string{"a", "b"}, tt.visits)
+
+		legs := state.MatchScore.Sets[0].Legs
+		if len(legs) != tt.wantLegs {
+			t.Errorf("%s: %d legs, want %d", tt.name, len(legs), tt.wantLegs)
+			continue
+		}
+		if got := legs[0].Result; got != tt.wantResult {
+			t.Errorf("%s: result %q, want %q", tt.name, got, tt.wantResult)
+		}
+		if got := deref(legs[0].WinnerID); got != tt.wantWinner {
+			t.Errorf("%s: leg won by %q, want %q", tt.name, got, tt.wantWinner)
+		}
+		if tt.wantResult == LegResultReplay && *state.Scores[0].Remaining != 101 {
+			t.Errorf("%s: replayed leg starts on %d, want 101", tt.name, *state.Scores[0].Remaining)
+		}
+	}
+}
+
+func TestX01LastVisit(t *testing.T) {
+	tests := []struct {
+		name   string
+		visits []testVisit
+		want   int
+	}{
+		{name: "scored", visits: []testVisit{v("a", "T20", "S20", "M")}, want: 80},
+		{name: "checkout", visits: []testVisit{v("a", "T20", "S20", "M"), v("b", "M"), v("a", "D10")}, want: 20},
+		{name: "darts after the checkout", visits: []testVisit{v("a", "T20", "S20", "M"), v("b", "M"), v("a", "D10", "T20", "T20")}, want: 20},
+		{name: "bust", visits: []testVisit{v("a", "T20", "S20", "M"), v("b", "M"), v("a", "T20")}, want: 80},
+	}
+	for _, tt := range tests {
+		// A single leg, so a checkout ends the match and stays on show.
+		config := GameConfig{Mode: "X01", StartingScore: intPtr(100)}
+		state := testGame(t, config, []string{"a", "b"}, tt.visits)
+		if got := state.Scores[0].LastVisit; got == nil || *got != tt.want {
+			t.Errorf("%s: last visit %v, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -11,12 +11,15 @@ func NewRouter(gh *game.Handler) http.Handler {
 	r := chi.NewRouter()
 
 	r.Route("/api", func(api chi.Router) {
+		api.Get("/modes", gh.ListModes) // GET /api/modes
+
 		api.Route("/games", func(gr chi.Router) {
-			gr.Post("/", gh.CreateGame)             // POST /api/games
-			gr.Get("/", gh.ListGames)               // GET  /api/games
-			gr.Get("/{id}", gh.GetGame)             // GET  /api/games/{id}
-			gr.Post("/{id}/throws", gh.PostThrow)   // POST /api/games/{id}/throws
-			gr.Post("/{id}/undo", gh.UndoLastThrow) // POST /api/games/{id}/undo
+			gr.Post("/", gh.CreateGame)               // POST /api/games
+			gr.Get("/", gh.ListGames)                 // GET  /api/games
+			gr.Get("/{id}", gh.GetGame)               // GET  /api/games/{id}
+			gr.Post("/{id}/throws", gh.PostThrow)     // POST /api/games/{id}/throws
+			gr.Post("/{id}/undo", gh.UndoLastThrow)   // POST /api/games/{id}/undo
+			gr.Post("/{id}/bull-off", gh.PostBullOff) // POST /api/games/{id}/bull-off
 		})
 	})
 